@@ -0,0 +1,57 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache wraps a Checker, refreshing its Report on a timer in the
+// background so a readiness probe that's hit frequently doesn't run an
+// expensive check (e.g. a Couchbase Ping) on every single request.
+type Cache struct {
+	checker Checker
+	timeout time.Duration
+
+	mu   sync.RWMutex
+	last Report
+}
+
+// NewCache returns a Cache that checks checker immediately, then again
+// every interval, each run bounded by timeout. The background goroutine
+// runs for the life of the process; Cache has no method to stop it.
+func NewCache(checker Checker, interval, timeout time.Duration) *Cache {
+	c := &Cache{checker: checker, timeout: timeout}
+	c.refresh()
+	go c.run(interval)
+	return c
+}
+
+func (c *Cache) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.refresh()
+	}
+}
+
+func (c *Cache) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	report := c.checker.Check(ctx)
+
+	c.mu.Lock()
+	c.last = report
+	c.mu.Unlock()
+}
+
+// Check returns the most recently cached Report. ctx is accepted to
+// satisfy Checker but is otherwise unused, since Check never itself
+// blocks on the wrapped dependency.
+func (c *Cache) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.last
+}
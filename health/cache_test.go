@@ -0,0 +1,29 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheRefreshesInBackground(t *testing.T) {
+	var calls int32
+
+	checker := CheckerFunc(func(ctx context.Context) Report {
+		atomic.AddInt32(&calls, 1)
+		return Report{Status: StatusUp}
+	})
+
+	cache := NewCache(checker, 10*time.Millisecond, time.Second)
+
+	if report := cache.Check(context.Background()); report.Status != StatusUp {
+		t.Fatalf("unexpected initial status: %s", report.Status)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if atomic.LoadInt32(&calls) < 2 {
+		t.Fatalf("expected checker to have been called more than once, got: %d", calls)
+	}
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+// requiredServices are the Couchbase services the travel API depends on:
+// N1QL for airport/flight queries, FTS for hotel search, and KV for user
+// and booking documents.
+var requiredServices = []gocb.ServiceType{
+	gocb.ServiceTypeQuery,
+	gocb.ServiceTypeSearch,
+	gocb.ServiceTypeKeyValue,
+}
+
+// CouchbaseChecker reports readiness by pinging the Query, Search, and
+// Key-Value services of a Couchbase cluster.
+type CouchbaseChecker struct {
+	cluster *gocb.Cluster
+	timeout time.Duration
+}
+
+// NewCouchbaseChecker returns a Checker that pings cluster's required
+// services, bounding each ping by timeout absent a shorter ctx deadline.
+func NewCouchbaseChecker(cluster *gocb.Cluster, timeout time.Duration) *CouchbaseChecker {
+	return &CouchbaseChecker{cluster: cluster, timeout: timeout}
+}
+
+func (c *CouchbaseChecker) Check(ctx context.Context) Report {
+	timeout := c.timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+
+	result, err := c.cluster.Ping(&gocb.PingOptions{
+		ServiceTypes: requiredServices,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		return Report{
+			Status: StatusDown,
+			Services: []ServiceStatus{
+				{Name: "couchbase", Status: StatusDown, Error: err.Error()},
+			},
+		}
+	}
+
+	report := Report{Status: StatusUp}
+	for _, svc := range requiredServices {
+		status := ServiceStatus{Name: serviceTypeName(svc), Status: StatusUp}
+
+		endpoints, ok := result.Services[svc]
+		if !ok || len(endpoints) == 0 {
+			status.Status = StatusDown
+			status.Error = "no endpoints reported"
+		} else {
+			for _, endpoint := range endpoints {
+				if endpoint.State != gocb.PingStateOk {
+					status.Status = StatusDown
+					status.Error = endpoint.Error
+					break
+				}
+			}
+		}
+
+		if status.Status == StatusDown {
+			report.Status = StatusDown
+		}
+		report.Services = append(report.Services, status)
+	}
+
+	return report
+}
+
+func serviceTypeName(s gocb.ServiceType) string {
+	switch s {
+	case gocb.ServiceTypeQuery:
+		return "query"
+	case gocb.ServiceTypeSearch:
+		return "search"
+	case gocb.ServiceTypeKeyValue:
+		return "kv"
+	default:
+		return "unknown"
+	}
+}
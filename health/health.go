@@ -0,0 +1,42 @@
+// Package health provides liveness/readiness checking for the travel API's
+// dependencies, independent of how those checks get exposed over HTTP.
+package health
+
+import "context"
+
+// Status is the up/down state of a single checked dependency, or of an
+// overall Report.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// ServiceStatus is the checked state of one dependency (e.g. one Couchbase
+// service).
+type ServiceStatus struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the result of running a Checker: an overall Status plus the
+// per-dependency detail behind it.
+type Report struct {
+	Status   Status          `json:"status"`
+	Services []ServiceStatus `json:"services"`
+}
+
+// Checker reports the current readiness of a dependency or set of
+// dependencies.
+type Checker interface {
+	Check(ctx context.Context) Report
+}
+
+// CheckerFunc adapts a plain function to a Checker.
+type CheckerFunc func(ctx context.Context) Report
+
+func (f CheckerFunc) Check(ctx context.Context) Report {
+	return f(ctx)
+}
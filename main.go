@@ -1,9 +1,14 @@
 package main
 
 import (
+	"log"
+	"net"
 	"net/http"
+	"os"
 
 	"github.com/couchbaselabs/try-cb-golang/server"
+	"github.com/couchbaselabs/try-cb-golang/service"
+	grpcapi "github.com/couchbaselabs/try-cb-golang/service/grpc"
 )
 
 func main() {
@@ -13,11 +18,35 @@ func main() {
 		panic(err)
 	}
 
-	server := server.New(db)
+	srv := server.New(db, server.WithReadinessChecker(server.ReadinessCheckerFromEnv(db)))
 
 	// Set up our routing
-	http.Handle("/", server)
+	http.Handle("/", srv)
 
-	// Listen on port 8080
-	http.ListenAndServe(":8080", server)
+	go serveGRPC(srv.Users)
+
+	addr := ":" + getEnv("PORT", "8080")
+	http.ListenAndServe(addr, srv)
+}
+
+// serveGRPC runs the gRPC UserService and TravelService alongside the HTTP API set up in
+// main, on its own port so the two transports don't have to share a
+// listener.
+func serveGRPC(users *service.Service) {
+	addr := ":" + getEnv("GRPC_PORT", "9090")
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal("failed to listen for grpc: ", err)
+	}
+
+	if err := grpcapi.NewServer(users).Serve(lis); err != nil {
+		log.Fatal("grpc server stopped: ", err)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
 }
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type requestLogStateKey struct{}
+
+// requestLogState accumulates the fields a handler learns over the
+// course of a request — who the caller turned out to be, and why a
+// non-2xx response happened — so accessLogMiddleware can fold them into
+// the single access log line it emits once the handler returns. It's
+// stored in the request context as a pointer so a handler's updates are
+// visible to the middleware without threading a value back out.
+type requestLogState struct {
+	user   string
+	reason string
+}
+
+func contextWithRequestLogState(ctx context.Context) (context.Context, *requestLogState) {
+	state := &requestLogState{}
+	return context.WithValue(ctx, requestLogStateKey{}, state), state
+}
+
+func requestLogStateFromContext(ctx context.Context) *requestLogState {
+	state, _ := ctx.Value(requestLogStateKey{}).(*requestLogState)
+	return state
+}
+
+// setRequestUser records the authenticated or target user for the
+// current request's access log line. A no-op outside accessLogMiddleware
+// (e.g. in a unit test that calls a handler directly).
+func setRequestUser(ctx context.Context, user string) {
+	if state := requestLogStateFromContext(ctx); state != nil {
+		state.user = user
+	}
+}
+
+// setRequestErrorReason records a short, stable classification (e.g.
+// "auth_failed", "not_found", "decode_error") for the current request's
+// access log line, so a 4xx/5xx can be grepped for without parsing the
+// response body. A no-op outside accessLogMiddleware.
+func setRequestErrorReason(ctx context.Context, reason string) {
+	if state := requestLogStateFromContext(ctx); state != nil {
+		state.reason = reason
+	}
+}
+
+// defaultReasonForStatus classifies a response status when the handler
+// didn't call setRequestErrorReason itself, so every non-2xx response
+// still gets some classification in the access log.
+func defaultReasonForStatus(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return "auth_failed"
+	case status == http.StatusNotFound:
+		return "not_found"
+	case status == http.StatusConflict:
+		return "conflict"
+	case status >= 400 && status < 500:
+		return "bad_request"
+	case status >= 500:
+		return "internal_error"
+	default:
+		return ""
+	}
+}
+
+// accessLogMiddleware emits exactly one structured log line per request,
+// after the handler has run, carrying the status, duration, response
+// size and whatever user/reason the handler recorded via setRequestUser
+// and setRequestErrorReason. It must run inside the router (via
+// Router.Use), after requestIDMiddleware so the request ID is already in
+// context, and wraps the response writer independently of
+// metricsMiddleware so either can be removed without affecting the
+// other.
+func (s *Server) accessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+
+		ctx, state := contextWithRequestLogState(req.Context())
+		req = req.WithContext(ctx)
+
+		rec := &statusRecordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, req)
+
+		reason := state.reason
+		if reason == "" {
+			reason = defaultReasonForStatus(rec.status)
+		}
+
+		attrs := []any{
+			"request_id", requestIDFromContext(req.Context()),
+			"route", routeLabel(req),
+			"method", req.Method,
+			"status", rec.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"bytes", rec.bytes,
+		}
+		if state.user != "" {
+			attrs = append(attrs, "user", state.user)
+		}
+		if reason != "" {
+			attrs = append(attrs, "reason", reason)
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case rec.status >= 500:
+			level = slog.LevelError
+		case rec.status >= 400:
+			level = slog.LevelWarn
+		}
+		s.Logger.Log(req.Context(), level, "http request", attrs...)
+	})
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureLogLines parses buf as a stream of slog.NewJSONHandler records,
+// one per line, into generic maps so a test can assert on individual
+// attributes without depending on slog's exact key ordering.
+func captureLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("error parsing log line %q: %v", line, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestAccessLogUserLoginAuthFailed(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := New(&mockRepo{
+		VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+			return false, nil
+		},
+	}, WithLogger(logger))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewBufferString(`{"user":"test_user","password":"wrong"}`))
+	server.ServeHTTP(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status: got %d want %d", w.Code, http.StatusUnauthorized)
+	}
+
+	lines := captureLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("unexpected number of access log lines: got %d want 1: %v", len(lines), lines)
+	}
+
+	line := lines[0]
+	if got := line["level"]; got != "WARN" {
+		t.Errorf("unexpected level: got %v want WARN", got)
+	}
+	if got := line["reason"]; got != "auth_failed" {
+		t.Errorf("unexpected reason: got %v want auth_failed", got)
+	}
+	if got := line["user"]; got != "test_user" {
+		t.Errorf("unexpected user: got %v want test_user", got)
+	}
+	if got := line["route"]; got != "/api/user/login" {
+		t.Errorf("unexpected route: got %v want /api/user/login", got)
+	}
+	if got, ok := line["request_id"].(string); !ok || got == "" {
+		t.Errorf("expected a non-empty request_id, got %v", line["request_id"])
+	}
+}
+
+func TestAccessLogSuccessHasNoReason(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := New(&mockRepo{
+		GetAirportsFn: func(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
+			return jsonAirportSearchResp{}, nil
+		},
+	}, WithLogger(logger))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/airports?search=SFO", nil)
+	server.ServeHTTP(w, r)
+
+	lines := captureLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("unexpected number of access log lines: got %d want 1: %v", len(lines), lines)
+	}
+
+	line := lines[0]
+	if got := line["level"]; got != "INFO" {
+		t.Errorf("unexpected level: got %v want INFO", got)
+	}
+	if _, ok := line["reason"]; ok {
+		t.Errorf("unexpected reason on a 200 response: %v", line["reason"])
+	}
+	if _, ok := line["bytes"]; !ok {
+		t.Errorf("expected a bytes attribute")
+	}
+}
+
+func TestAccessLogDecodeErrorReason(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := New(&mockRepo{}, WithLogger(logger))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewBufferString(`{"user":}`))
+	server.ServeHTTP(w, r)
+
+	lines := captureLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("unexpected number of access log lines: got %d want 1: %v", len(lines), lines)
+	}
+	if got := lines[0]["reason"]; got != "decode_error" {
+		t.Errorf("unexpected reason: got %v want decode_error", got)
+	}
+}
@@ -0,0 +1,265 @@
+package server
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Authenticator issues and verifies the bearer tokens used to authenticate
+// requests to the user-scoped endpoints. It lets the server swap out the
+// signing scheme (a shared HMAC secret, a remote OIDC provider, ...)
+// without handlers needing to know which one is in effect.
+type Authenticator interface {
+	// Issue mints a new bearer token for user.
+	Issue(user string) (string, error)
+
+	// Verify validates token and returns the user it authenticates.
+	Verify(token string) (authedUser, error)
+}
+
+// defaultTokenTTL bounds how long an issued token remains valid.
+const defaultTokenTTL = 24 * time.Hour
+
+// HMACAuthenticator is the default Authenticator. It signs and verifies
+// tokens with a single shared secret.
+type HMACAuthenticator struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewHMACAuthenticator returns an Authenticator that signs tokens with
+// HS256 using secret.
+func NewHMACAuthenticator(secret []byte) *HMACAuthenticator {
+	return &HMACAuthenticator{secret: secret, ttl: defaultTokenTTL}
+}
+
+// hmacAuthenticatorFromEnv builds the default HMAC Authenticator, reading
+// the signing secret from JWT_SECRET and falling back to a built-in
+// development value when it is unset.
+func hmacAuthenticatorFromEnv() *HMACAuthenticator {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		// Falls back to a well-known value so local development and the
+		// existing test suite keep working without extra setup; this is
+		// not suitable for production and should be overridden via
+		// JWT_SECRET or a WithAuthenticator option.
+		secret = "UNSECURE_SECRET_TOKEN"
+	}
+
+	return NewHMACAuthenticator([]byte(secret))
+}
+
+// authenticatorFromEnv builds the default Authenticator: an
+// OIDCAuthenticator if OIDC_ISSUER is set, otherwise the HMAC
+// Authenticator.
+func authenticatorFromEnv() Authenticator {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return hmacAuthenticatorFromEnv()
+	}
+
+	return NewOIDCAuthenticator(issuer, os.Getenv("OIDC_AUDIENCE"), os.Getenv("OIDC_JWKS_URL"))
+}
+
+func (a *HMACAuthenticator) Issue(user string) (string, error) {
+	now := time.Now()
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user": user,
+		"iat":  jwt.NewNumericDate(now),
+		"exp":  jwt.NewNumericDate(now.Add(a.ttl)),
+	}).SignedString(a.secret)
+}
+
+func (a *HMACAuthenticator) Verify(tokenStr string) (authedUser, error) {
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return a.secret, nil
+	})
+	if err != nil {
+		return authedUser{}, err
+	}
+
+	return authedUserFromClaims(token)
+}
+
+func authedUserFromClaims(token *jwt.Token) (authedUser, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return authedUser{}, ErrBadAuth
+	}
+
+	user, _ := claims["user"].(string)
+	if user == "" {
+		return authedUser{}, ErrBadAuth
+	}
+
+	return authedUser{Name: user}, nil
+}
+
+// jwksRefreshInterval bounds how long a fetched JWKS document is trusted
+// before OIDCAuthenticator fetches it again.
+const jwksRefreshInterval = time.Hour
+
+// OIDCAuthenticator verifies tokens issued by a remote OIDC provider,
+// fetching and caching that provider's signing keys from its JWKS
+// endpoint. It does not issue tokens itself, since federated sessions are
+// minted by the provider.
+type OIDCAuthenticator struct {
+	issuer   string
+	audience string
+	jwksURL  string
+
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	keysByKid map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewOIDCAuthenticator returns an Authenticator that validates tokens
+// against issuer's JWKS document served at jwksURL, requiring an audience
+// of aud.
+func NewOIDCAuthenticator(issuer, aud, jwksURL string) *OIDCAuthenticator {
+	return &OIDCAuthenticator{
+		issuer:     issuer,
+		audience:   aud,
+		jwksURL:    jwksURL,
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (a *OIDCAuthenticator) Issue(user string) (string, error) {
+	return "", errors.New("OIDCAuthenticator does not issue tokens; tokens come from the OIDC provider")
+}
+
+func (a *OIDCAuthenticator) Verify(tokenStr string) (authedUser, error) {
+	token, err := jwt.Parse(tokenStr, a.keyFunc, jwt.WithIssuer(a.issuer), jwt.WithAudience(a.audience))
+	if err != nil {
+		return authedUser{}, err
+	}
+
+	return authedUserFromClaims(token)
+}
+
+func (a *OIDCAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token is missing a kid header")
+	}
+
+	return a.key(kid)
+}
+
+// key returns the public key for kid, refreshing the cached JWKS document
+// if it is stale or the key is unknown.
+func (a *OIDCAuthenticator) key(kid string) (*rsa.PublicKey, error) {
+	a.mu.RLock()
+	key, ok := a.keysByKid[kid]
+	fresh := time.Since(a.fetchedAt) < jwksRefreshInterval
+	a.mu.RUnlock()
+
+	if ok && fresh {
+		return key, nil
+	}
+
+	if err := a.refreshKeys(); err != nil {
+		if ok {
+			// Serve the stale key rather than failing every request
+			// because the IdP is briefly unreachable.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	key, ok = a.keysByKid[kid]
+	if !ok {
+		return nil, fmt.Errorf("no signing key found for kid %q", kid)
+	}
+
+	return key, nil
+}
+
+type jwksDocument struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func (a *OIDCAuthenticator) refreshKeys() error {
+	resp, err := a.httpClient.Get(a.jwksURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+
+		key, err := rsaPublicKeyFromJWK(jwk)
+		if err != nil {
+			return fmt.Errorf("parsing JWKS key %q: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	a.mu.Lock()
+	a.keysByKid = keys
+	a.fetchedAt = time.Now()
+	a.mu.Unlock()
+
+	return nil
+}
+
+func rsaPublicKeyFromJWK(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("decoding modulus: %w", err)
+	}
+
+	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("decoding exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
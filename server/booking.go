@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+)
+
+// ErrBookingNotFound is returned when a booking ID doesn't resolve to a
+// stored document.
+var ErrBookingNotFound = errors.New("booking does not exist")
+
+// ErrInvalidBookingStatus is returned when a requested status transition
+// isn't reachable from a booking's current status.
+var ErrInvalidBookingStatus = errors.New("invalid booking status transition")
+
+// BookingRepository stores and mutates bookings made through the v2 API.
+// It is kept separate from Repository because a booking's CAS-guarded
+// status transitions are a distinct concern from the read-mostly flight,
+// hotel and legacy user-flights lookups Repository exposes.
+type BookingRepository interface {
+	CreateBooking(ctx context.Context, username string, flights []jsonBookedFlight) (jsonBooking, error)
+	GetBooking(ctx context.Context, id string) (jsonBooking, error)
+	UpdateBookingStatus(ctx context.Context, id string, status BookingStatus) (jsonBooking, error)
+}
+
+// bookingStatusTransitions enumerates the statuses reachable from each
+// booking status. A status is terminal if it has no entry.
+var bookingStatusTransitions = map[BookingStatus][]BookingStatus{
+	BookingWaitingConfirmation: {BookingConfirmed, BookingCancelled},
+	BookingConfirmed:           {BookingCompleted, BookingCancelled},
+}
+
+func canTransitionBookingStatus(from, to BookingStatus) bool {
+	for _, allowed := range bookingStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// bookingsCollection returns the userData.bookings collection, the home
+// for documents keyed by booking UUID.
+func (cr *CBRepository) bookingsCollection() *gocb.Collection {
+	return cr.userBucket.Scope("userData").Collection("bookings")
+}
+
+func (cr *CBRepository) CreateBooking(ctx context.Context, username string, flights []jsonBookedFlight) (jsonBooking, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonBooking{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return jsonBooking{}, err
+	}
+
+	booking := jsonBooking{
+		ID:       id.String(),
+		User:     username,
+		Status:   BookingWaitingConfirmation,
+		Flights:  flights,
+		BookedOn: time.Now().Format("01/02/2006"),
+	}
+
+	if _, err := cr.bookingsCollection().Insert(booking.ID, booking, &gocb.InsertOptions{Timeout: timeoutFromContext(ctx)}); err != nil {
+		return jsonBooking{}, err
+	}
+
+	return booking, nil
+}
+
+func (cr *CBRepository) GetBooking(ctx context.Context, id string) (jsonBooking, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonBooking{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	res, err := cr.bookingsCollection().Get(id, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
+	if errors.Is(err, gocb.ErrDocumentNotFound) {
+		return jsonBooking{}, ErrBookingNotFound
+	} else if err != nil {
+		return jsonBooking{}, err
+	}
+
+	var booking jsonBooking
+	if err := res.Content(&booking); err != nil {
+		return jsonBooking{}, err
+	}
+
+	return booking, nil
+}
+
+// UpdateBookingStatus moves the booking's status forward along the
+// WAITING_CONFIRMATION -> CONFIRMED|CANCELLED -> COMPLETED|CANCELLED
+// lifecycle, guarding the read-modify-write with the document's CAS so a
+// concurrent status change is rejected rather than silently overwritten.
+func (cr *CBRepository) UpdateBookingStatus(ctx context.Context, id string, status BookingStatus) (jsonBooking, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonBooking{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	res, err := cr.bookingsCollection().Get(id, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
+	if errors.Is(err, gocb.ErrDocumentNotFound) {
+		return jsonBooking{}, ErrBookingNotFound
+	} else if err != nil {
+		return jsonBooking{}, err
+	}
+
+	cas := res.Cas()
+	var booking jsonBooking
+	if err := res.Content(&booking); err != nil {
+		return jsonBooking{}, err
+	}
+
+	if !canTransitionBookingStatus(booking.Status, status) {
+		return jsonBooking{}, ErrInvalidBookingStatus
+	}
+
+	booking.Status = status
+
+	opts := gocb.ReplaceOptions{Cas: cas, Timeout: timeoutFromContext(ctx)}
+	if _, err := cr.bookingsCollection().Replace(id, booking, &opts); err != nil {
+		// A CAS mismatch here means another request changed the booking's
+		// status concurrently; surface it rather than silently retrying,
+		// same as UpdateUserFlights does for the user document.
+		return jsonBooking{}, err
+	}
+
+	return booking, nil
+}
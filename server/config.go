@@ -0,0 +1,95 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RepositoryConfig configures a CBRepository's connection to Couchbase.
+// Every field has an env var, falling back to the original hardcoded
+// defaults so a local/dev setup keeps working unconfigured.
+type RepositoryConfig struct {
+	ConnString string
+	DataBucket string
+	UserBucket string
+	Username   string
+	Password   string
+}
+
+// RepositoryConfigFromEnv builds a RepositoryConfig from CB_CONN_STRING,
+// CB_DATA_BUCKET, CB_USER_BUCKET, CB_USERNAME and CB_PASSWORD.
+func RepositoryConfigFromEnv() RepositoryConfig {
+	return RepositoryConfig{
+		ConnString: getEnv("CB_CONN_STRING", "couchbase://localhost"),
+		DataBucket: getEnv("CB_DATA_BUCKET", "travel-sample"),
+		UserBucket: getEnv("CB_USER_BUCKET", "travel-users"),
+		Username:   getEnv("CB_USERNAME", "Administrator"),
+		Password:   getEnv("CB_PASSWORD", "password"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+func getEnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+// adminUsersFromEnv builds the default admin allowlist from ADMIN_USERS, a
+// comma-separated list of usernames, e.g. "alice,bob". Empty (no admins)
+// unless set.
+func adminUsersFromEnv() []string {
+	v := os.Getenv("ADMIN_USERS")
+	if v == "" {
+		return nil
+	}
+
+	var users []string
+	for _, u := range strings.Split(v, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			users = append(users, u)
+		}
+	}
+	return users
+}
@@ -1,23 +1,19 @@
 package server
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"time"
 
-	"github.com/couchbase/gocb/v2"
 	"github.com/gorilla/mux"
+
+	"github.com/couchbaselabs/try-cb-golang/service"
 )
 
 var (
-	ErrUserExists    = errors.New("user already exists")
-	ErrUserNotFound  = errors.New("user does not exist")
-	ErrBadPassword   = errors.New("password does not match")
 	ErrBadAuthHeader = errors.New("bad authentication header format")
 	ErrBadAuth       = errors.New("invalid auth token")
-
-	jwtSecret = []byte("UNSECURE_SECRET_TOKEN")
 )
 
 // GET /api/airports?search=xxx
@@ -27,14 +23,22 @@ type jsonAirportSearchResp struct {
 }
 
 func (s *Server) AirportSearch(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	searchKey := req.FormValue("search")
 
-	respData, err := s.db.GetAirports(searchKey)
+	airports, queryCtx, err := s.Users.SearchAirports(ctx, searchKey)
 	if err != nil {
-		writeJsonFailure(w, 500, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
+	respData := jsonAirportSearchResp{Context: jsonContext(queryCtx)}
+	for _, a := range airports {
+		respData.Data = append(respData.Data, jsonAirport(a))
+	}
+
 	encodeRespOrFail(w, respData)
 }
 
@@ -45,6 +49,9 @@ type jsonFlightSearchResp struct {
 }
 
 func (s *Server) FlightSearch(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	reqVars := mux.Vars(req)
 
 	leaveDate, err := time.Parse("01/02/2006", req.FormValue("leave"))
@@ -53,14 +60,18 @@ func (s *Server) FlightSearch(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	var respData jsonFlightSearchResp
 	dayOfWeek := int(leaveDate.Weekday())
-	respData, err = s.db.GetFlightPaths(reqVars["from"], reqVars["to"], dayOfWeek)
+	flights, queryCtx, err := s.Users.SearchFlightPaths(ctx, reqVars["from"], reqVars["to"], dayOfWeek)
 	if err != nil {
-		writeJsonFailure(w, 500, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
+	respData := jsonFlightSearchResp{Context: jsonContext(queryCtx)}
+	for _, f := range flights {
+		respData.Data = append(respData.Data, jsonFlight(f))
+	}
+
 	encodeRespOrFail(w, respData)
 }
 
@@ -71,17 +82,25 @@ type jsonHotelSearchResp struct {
 }
 
 func (s *Server) HotelSearch(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	reqVars := mux.Vars(req)
 
 	description := reqVars["description"]
 	location := reqVars["location"]
 
-	respData, err := s.db.GetHotels(description, location)
+	hotels, queryCtx, err := s.Users.SearchHotels(ctx, description, location)
 	if err != nil {
-		writeJsonFailure(w, 500, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
+	respData := jsonHotelSearchResp{Context: jsonContext(queryCtx)}
+	for _, h := range hotels {
+		respData.Data = append(respData.Data, jsonHotel(h))
+	}
+
 	encodeRespOrFail(w, respData)
 }
 
@@ -99,29 +118,29 @@ type jsonUserLoginResp struct {
 }
 
 func (s *Server) UserLogin(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	var respData jsonUserLoginResp
 
 	var reqData jsonUserLoginReq
 	if !decodeReqOrFail(w, req, &reqData) {
 		return
 	}
-
-	password, err := s.db.GetUserPassword(reqData.User)
-	if errors.Is(err, gocb.ErrDocumentNotFound) {
-		writeJsonFailure(w, 401, ErrUserNotFound)
-		return
-	} else if err != nil {
-		fmt.Println(errors.Unwrap(err))
-		writeJsonFailure(w, 500, err)
-		return
-	}
-
-	if password != reqData.Password {
-		writeJsonFailure(w, 401, ErrBadPassword)
+	setRequestUser(ctx, reqData.User)
+
+	if err := s.Users.Login(ctx, reqData.User, reqData.Password); err != nil {
+		if errors.Is(err, service.ErrBadAuth) {
+			setRequestErrorReason(ctx, "auth_failed")
+			writeJsonFailure(w, 401, err)
+			return
+		}
+		s.logRepoError(req, reqData.User, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
-	token, err := createJwtToken(reqData.User)
+	token, err := s.Auth.Issue(reqData.User)
 	if err != nil {
 		writeJsonFailure(w, 500, err)
 		return
@@ -132,10 +151,14 @@ func (s *Server) UserLogin(w http.ResponseWriter, req *http.Request) {
 	encodeRespOrFail(w, respData)
 }
 
-//POST /api/user/signup
+// POST /api/user/signup
 type jsonUserSignupReq struct {
 	User     string `json:"user"`
 	Password string `json:"password"`
+
+	// RegistrationToken is required when Server.RegistrationRequiresToken
+	// is set, and ignored otherwise.
+	RegistrationToken string `json:"registration_token,omitempty"`
 }
 
 type jsonUserSignupResp struct {
@@ -146,24 +169,63 @@ type jsonUserSignupResp struct {
 }
 
 func (s *Server) UserSignup(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	var respData jsonUserSignupResp
 
 	var reqData jsonUserSignupReq
 	if !decodeReqOrFail(w, req, &reqData) {
 		return
 	}
+	setRequestUser(ctx, reqData.User)
+
+	var reservedTokenID string
+	if s.RegistrationRequiresToken {
+		if s.RegistrationTokens == nil {
+			writeJsonFailure(w, 500, errors.New("registration tokens are not configured on this server"))
+			return
+		}
+
+		id, err := s.RegistrationTokens.ReserveRegistrationToken(ctx, reqData.RegistrationToken)
+		if errors.Is(err, ErrRegistrationTokenInvalid) {
+			setRequestErrorReason(ctx, "registration_token_invalid")
+			writeJsonFailure(w, 401, err)
+			return
+		} else if err != nil {
+			s.logRepoError(req, reqData.User, err)
+			writeJsonFailure(w, statusForErr(err), err)
+			return
+		}
+		reservedTokenID = id
+	}
 
-	err := s.db.CreateUser(reqData.User, reqData.Password)
-	if errors.Is(err, gocb.ErrDocumentExists) {
-		writeJsonFailure(w, 409, ErrUserExists)
-		return
-	} else if err != nil {
-		fmt.Println(errors.Unwrap(err))
-		writeJsonFailure(w, 500, err)
+	if err := s.Users.Signup(ctx, reqData.User, reqData.Password); err != nil {
+		if reservedTokenID != "" {
+			// Best-effort: don't let a release failure mask the real
+			// signup error, but don't leave the token charged for an
+			// account that was never created either.
+			if releaseErr := s.RegistrationTokens.ReleaseRegistrationToken(ctx, reservedTokenID); releaseErr != nil {
+				s.logRepoError(req, reqData.User, releaseErr)
+			}
+		}
+		if errors.Is(err, service.ErrUserExists) {
+			setRequestErrorReason(ctx, "user_exists")
+			writeJsonFailure(w, 409, err)
+			return
+		}
+		s.logRepoError(req, reqData.User, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
-	token, err := createJwtToken(reqData.User)
+	if reservedTokenID != "" {
+		if err := s.RegistrationTokens.CompleteRegistrationToken(ctx, reservedTokenID); err != nil {
+			s.logRepoError(req, reqData.User, err)
+		}
+	}
+
+	token, err := s.Auth.Issue(reqData.User)
 	if err != nil {
 		writeJsonFailure(w, 500, err)
 		return
@@ -181,23 +243,31 @@ type jsonUserFlightsResp struct {
 }
 
 func (s *Server) UserFlights(w http.ResponseWriter, req *http.Request) {
-	var respData jsonUserFlightsResp
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	var authUser authedUser
 
-	if !decodeAuthUserOrFail(w, req, &authUser) {
+	if !s.decodeAuthUserOrFail(w, req, &authUser) {
 		return
 	}
 
-	respData, err := s.db.GetUserFlights(authUser.Name)
+	flights, queryCtx, err := s.Users.GetUserFlights(ctx, authUser.Name)
 	if err != nil {
-		writeJsonFailure(w, 500, err)
+		s.logRepoError(req, authUser.Name, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
+	respData := jsonUserFlightsResp{Context: jsonContext(queryCtx)}
+	for _, f := range flights {
+		respData.Data = append(respData.Data, jsonBookedFlight(f))
+	}
+
 	encodeRespOrFail(w, respData)
 }
 
-//POST  /api/user/{username}/flights
+// POST  /api/user/{username}/flights
 type jsonUserBookFlightReq struct {
 	Flights []jsonBookedFlight `json:"flights"`
 }
@@ -210,11 +280,13 @@ type jsonUserBookFlightResp struct {
 }
 
 func (s *Server) UserBookFlight(w http.ResponseWriter, req *http.Request) {
-	var respData jsonUserBookFlightResp
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
 	var reqData jsonUserBookFlightReq
 	var authUser authedUser
 
-	if !decodeAuthUserOrFail(w, req, &authUser) {
+	if !s.decodeAuthUserOrFail(w, req, &authUser) {
 		return
 	}
 
@@ -222,11 +294,23 @@ func (s *Server) UserBookFlight(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	respData, err := s.db.UpdateUserFlights(authUser.Name, reqData.Flights)
+	flights := make([]service.BookedFlight, len(reqData.Flights))
+	for i, f := range reqData.Flights {
+		flights[i] = service.BookedFlight(f)
+	}
+
+	added, queryCtx, err := s.Users.BookFlights(ctx, authUser.Name, flights)
 	if err != nil {
-		writeJsonFailure(w, 500, err)
+		s.logRepoError(req, authUser.Name, err)
+		writeJsonFailure(w, statusForErr(err), err)
 		return
 	}
 
+	var respData jsonUserBookFlightResp
+	respData.Context = jsonContext(queryCtx)
+	for _, f := range added {
+		respData.Data.Added = append(respData.Data.Added, jsonBookedFlight(f))
+	}
+
 	encodeRespOrFail(w, respData)
 }
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+var (
+	ErrUnknownConnector = errors.New("unknown auth connector")
+	ErrBadAuthState     = errors.New("missing or mismatched oauth state")
+)
+
+// connectorStateCookieTTL bounds how long the state cookie set by
+// AuthConnectorStart remains valid, covering how long a user may sit on
+// an identity provider's consent screen.
+const connectorStateCookieTTL = 10 * time.Minute
+
+func connectorStateCookieName(name string) string {
+	return "oauth_state_" + name
+}
+
+// randomToken returns a random, URL-safe token suitable for OAuth2 state
+// and nonce values.
+func randomToken() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// GET /api/user/auth/{connector}/start
+func (s *Server) AuthConnectorStart(w http.ResponseWriter, req *http.Request) {
+	connector, ok := s.Connectors[mux.Vars(req)["connector"]]
+	if !ok {
+		writeJsonFailure(w, http.StatusNotFound, ErrUnknownConnector)
+		return
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		writeJsonFailure(w, 500, err)
+		return
+	}
+
+	nonce, err := randomToken()
+	if err != nil {
+		writeJsonFailure(w, 500, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     connectorStateCookieName(connector.Name()),
+		Value:    state,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(connectorStateCookieTTL.Seconds()),
+	})
+
+	http.Redirect(w, req, connector.AuthURL(state, nonce), http.StatusFound)
+}
+
+// GET /api/user/auth/{connector}/callback
+type jsonAuthConnectorCallbackResp struct {
+	Data struct {
+		Token string `json:"token"`
+	} `json:"data"`
+	Context jsonContext `json:"context"`
+}
+
+func (s *Server) AuthConnectorCallback(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
+	connector, ok := s.Connectors[mux.Vars(req)["connector"]]
+	if !ok {
+		writeJsonFailure(w, http.StatusNotFound, ErrUnknownConnector)
+		return
+	}
+
+	stateCookie, err := req.Cookie(connectorStateCookieName(connector.Name()))
+	if err != nil || stateCookie.Value == "" || stateCookie.Value != req.FormValue("state") {
+		writeJsonFailure(w, 400, ErrBadAuthState)
+		return
+	}
+
+	profile, err := connector.Exchange(ctx, req.FormValue("code"))
+	if err != nil {
+		s.logRepoError(req, "", err)
+		writeJsonFailure(w, statusForErr(err), err)
+		return
+	}
+
+	username, err := s.db.GetOrCreateFederatedUser(ctx, connector.Name(), profile.Subject, profile)
+	if errors.Is(err, ErrFederatedAccountCollision) {
+		setRequestErrorReason(ctx, "federated_account_collision")
+		writeJsonFailure(w, 409, err)
+		return
+	} else if err != nil {
+		s.logRepoError(req, username, err)
+		writeJsonFailure(w, statusForErr(err), err)
+		return
+	}
+
+	token, err := s.Auth.Issue(username)
+	if err != nil {
+		writeJsonFailure(w, 500, err)
+		return
+	}
+
+	var respData jsonAuthConnectorCallbackResp
+	respData.Data.Token = token
+
+	encodeRespOrFail(w, respData)
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type stubConnector struct {
+	name       string
+	exchangeFn func(ctx context.Context, code string) (FederatedProfile, error)
+}
+
+func (c *stubConnector) Name() string { return c.name }
+
+func (c *stubConnector) AuthURL(state, nonce string) string {
+	return "https://idp.example.com/authorize?state=" + state + "&nonce=" + nonce
+}
+
+func (c *stubConnector) Exchange(ctx context.Context, code string) (FederatedProfile, error) {
+	return c.exchangeFn(ctx, code)
+}
+
+func TestAuthConnectorStart(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title      string
+		connector  string
+		wantStatus int
+	}{
+		{
+			title:      "302 - redirects to provider",
+			connector:  "github",
+			wantStatus: http.StatusFound,
+		},
+		{
+			title:      "404 - unknown connector",
+			connector:  "boom",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/user/auth/"+tc.connector+"/start", nil)
+
+			server := New(&mockRepo{}, WithAuthConnectors(&stubConnector{name: "github"}))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAuthConnectorCallback(t *testing.T) {
+	t.Parallel()
+
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+
+	testCases := []struct {
+		title         string
+		connector     string
+		state         string
+		cookieState   string
+		connectorFn   func(ctx context.Context, code string) (FederatedProfile, error)
+		repository    Repository
+		wantStatus    int
+		wantTokenUser string
+	}{
+		{
+			title:       "200 - ok new user",
+			connector:   "github",
+			state:       "good_state",
+			cookieState: "good_state",
+			connectorFn: func(ctx context.Context, code string) (FederatedProfile, error) {
+				return FederatedProfile{Subject: "123", Email: "user@example.com"}, nil
+			},
+			repository: &mockRepo{
+				GetOrCreateFederatedUserFn: func(ctx context.Context, provider, subject string, profile FederatedProfile) (string, error) {
+					if provider != "github" || subject != "123" {
+						t.Errorf("unexpected provider/subject, got: %s/%s want: github/123", provider, subject)
+					}
+					return "github:123", nil
+				},
+			},
+
+			wantStatus:    http.StatusOK,
+			wantTokenUser: "github:123",
+		},
+		{
+			title:       "400 - state mismatch",
+			connector:   "github",
+			state:       "bad_state",
+			cookieState: "good_state",
+			connectorFn: func(ctx context.Context, code string) (FederatedProfile, error) {
+				return FederatedProfile{}, nil
+			},
+			repository: &mockRepo{},
+
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			title:       "409 - username already registered as a password account",
+			connector:   "github",
+			state:       "good_state",
+			cookieState: "good_state",
+			connectorFn: func(ctx context.Context, code string) (FederatedProfile, error) {
+				return FederatedProfile{Subject: "123", Email: "user@example.com"}, nil
+			},
+			repository: &mockRepo{
+				GetOrCreateFederatedUserFn: func(ctx context.Context, provider, subject string, profile FederatedProfile) (string, error) {
+					return "", ErrFederatedAccountCollision
+				},
+			},
+
+			wantStatus: http.StatusConflict,
+		},
+		{
+			title:       "500 - exchange error",
+			connector:   "github",
+			state:       "good_state",
+			cookieState: "good_state",
+			connectorFn: func(ctx context.Context, code string) (FederatedProfile, error) {
+				return FederatedProfile{}, errors.New("boom")
+			},
+			repository: &mockRepo{},
+
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			connector := &stubConnector{name: tc.connector, exchangeFn: tc.connectorFn}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/user/auth/"+tc.connector+"/callback?state="+tc.state+"&code=abc", nil)
+			r.AddCookie(&http.Cookie{Name: connectorStateCookieName(tc.connector), Value: tc.cookieState})
+
+			server := New(tc.repository, WithAuthenticator(auth), WithAuthConnectors(connector))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantTokenUser == "" {
+				return
+			}
+
+			var gotResp jsonAuthConnectorCallbackResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+
+			authedU, err := auth.Verify(gotResp.Data.Token)
+			if err != nil {
+				t.Fatalf("error verifying issued token: %v", err)
+			}
+			if authedU.Name != tc.wantTokenUser {
+				t.Errorf("unexpected token user, got: %s want: %s", authedU.Name, tc.wantTokenUser)
+			}
+		})
+	}
+}
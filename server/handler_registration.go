@@ -0,0 +1,157 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// defaultRegistrationTokenListLimit bounds how many registration tokens
+// ListRegistrationTokens returns when the caller doesn't specify limit.
+const defaultRegistrationTokenListLimit = 20
+
+type jsonRegistrationTokenResp struct {
+	Data jsonRegistrationToken `json:"data"`
+}
+
+type jsonRegistrationTokensResp struct {
+	Data []jsonRegistrationToken `json:"data"`
+}
+
+// GET /api/admin/registration_tokens?from=...&limit=N
+func (s *Server) ListRegistrationTokens(w http.ResponseWriter, req *http.Request) {
+	if !s.requireAdmin(w, req) {
+		return
+	}
+
+	if s.RegistrationTokens == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "registration tokens are not configured on this server")
+		return
+	}
+
+	q := req.URL.Query()
+
+	limit := defaultRegistrationTokenListLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeJsonErrorV2(w, 400, "invalid_limit", "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	tokens, err := s.RegistrationTokens.ListRegistrationTokens(req.Context(), q.Get("from"), limit)
+	if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonRegistrationTokensResp{Data: tokens})
+}
+
+// POST /api/admin/registration_tokens
+func (s *Server) CreateRegistrationToken(w http.ResponseWriter, req *http.Request) {
+	if !s.requireAdmin(w, req) {
+		return
+	}
+
+	if s.RegistrationTokens == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "registration tokens are not configured on this server")
+		return
+	}
+
+	var reqData jsonRegistrationToken
+	if err := decodeReqOrFailV2(w, req, &reqData); err != nil {
+		return
+	}
+
+	token, err := s.RegistrationTokens.CreateRegistrationToken(req.Context(), reqData)
+	if errors.Is(err, ErrRegistrationTokenMalformed) {
+		writeJsonErrorV2(w, 400, "malformed_token", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonRegistrationTokenResp{Data: token})
+}
+
+// GET /api/admin/registration_tokens/{id}
+func (s *Server) GetRegistrationToken(w http.ResponseWriter, req *http.Request) {
+	if !s.requireAdmin(w, req) {
+		return
+	}
+
+	if s.RegistrationTokens == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "registration tokens are not configured on this server")
+		return
+	}
+
+	id := mux.Vars(req)["id"]
+	token, err := s.RegistrationTokens.GetRegistrationToken(req.Context(), id)
+	if errors.Is(err, ErrRegistrationTokenNotFound) {
+		writeJsonErrorV2(w, 404, "registration_token_not_found", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonRegistrationTokenResp{Data: token})
+}
+
+// PUT /api/admin/registration_tokens/{id}
+func (s *Server) UpdateRegistrationToken(w http.ResponseWriter, req *http.Request) {
+	if !s.requireAdmin(w, req) {
+		return
+	}
+
+	if s.RegistrationTokens == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "registration tokens are not configured on this server")
+		return
+	}
+
+	var reqData jsonRegistrationToken
+	if err := decodeReqOrFailV2(w, req, &reqData); err != nil {
+		return
+	}
+
+	id := mux.Vars(req)["id"]
+	token, err := s.RegistrationTokens.UpdateRegistrationToken(req.Context(), id, reqData)
+	if errors.Is(err, ErrRegistrationTokenNotFound) {
+		writeJsonErrorV2(w, 404, "registration_token_not_found", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonRegistrationTokenResp{Data: token})
+}
+
+// DELETE /api/admin/registration_tokens/{id}
+func (s *Server) DeleteRegistrationToken(w http.ResponseWriter, req *http.Request) {
+	if !s.requireAdmin(w, req) {
+		return
+	}
+
+	if s.RegistrationTokens == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "registration tokens are not configured on this server")
+		return
+	}
+
+	id := mux.Vars(req)["id"]
+	if err := s.RegistrationTokens.DeleteRegistrationToken(req.Context(), id); errors.Is(err, ErrRegistrationTokenNotFound) {
+		writeJsonErrorV2(w, 404, "registration_token_not_found", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,381 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// adminTestAuth and adminToken back every registration-token handler test:
+// the handlers now require the caller to be an authenticated admin.
+var adminTestAuth = NewHMACAuthenticator([]byte("test_secret"))
+
+func adminTestToken(t *testing.T) string {
+	t.Helper()
+
+	token, err := adminTestAuth.Issue("admin_user")
+	if err != nil {
+		t.Fatal("error creating test admin jwt token:", err)
+	}
+	return "Bearer " + token
+}
+
+func TestListRegistrationTokens(t *testing.T) {
+	t.Parallel()
+
+	validData := []jsonRegistrationToken{{ID: "abc123", Token: "abc123"}}
+	adminToken := adminTestToken(t)
+
+	testCases := []struct {
+		title    string
+		endpoint string
+		token    string
+		rtr      RegistrationTokenRepository
+
+		wantStatus int
+		wantResp   jsonRegistrationTokensResp
+	}{
+		{
+			title:    "200 - ok",
+			endpoint: "/api/admin/registration_tokens?from=a&limit=10",
+			token:    adminToken,
+			rtr: &mockRegistrationTokenRepo{
+				ListRegistrationTokensFn: func(ctx context.Context, from string, limit int) ([]jsonRegistrationToken, error) {
+					if from != "a" || limit != 10 {
+						t.Errorf("unexpected from/limit, got: %s/%d want: a/10", from, limit)
+					}
+					return validData, nil
+				},
+			},
+
+			wantStatus: http.StatusOK,
+			wantResp:   jsonRegistrationTokensResp{Data: validData},
+		},
+		{
+			title:      "400 - invalid limit",
+			endpoint:   "/api/admin/registration_tokens?limit=boom",
+			token:      adminToken,
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			title:    "500 - error querying data",
+			endpoint: "/api/admin/registration_tokens",
+			token:    adminToken,
+			rtr: &mockRegistrationTokenRepo{
+				ListRegistrationTokensFn: func(ctx context.Context, from string, limit int) ([]jsonRegistrationToken, error) {
+					return nil, errors.New("boom")
+				},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			title:      "500 - registration tokens not configured",
+			endpoint:   "/api/admin/registration_tokens",
+			token:      adminToken,
+			rtr:        nil,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			title:      "400 - missing auth",
+			endpoint:   "/api/admin/registration_tokens",
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			title:      "403 - authenticated but not an admin",
+			endpoint:   "/api/admin/registration_tokens",
+			token:      "Bearer " + mustIssueToken(t, adminTestAuth, "not_an_admin"),
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tc.endpoint, nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", tc.token)
+			}
+
+			opts := []Option{WithAuthenticator(adminTestAuth), WithAdminUsers("admin_user")}
+			if tc.rtr != nil {
+				opts = append(opts, WithRegistrationTokenRepository(tc.rtr))
+			}
+			server := New(&mockRepo{}, opts...)
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gotResp jsonRegistrationTokensResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
+			}
+		})
+	}
+}
+
+func mustIssueToken(t *testing.T, auth *HMACAuthenticator, user string) string {
+	t.Helper()
+
+	token, err := auth.Issue(user)
+	if err != nil {
+		t.Fatal("error creating test jwt token:", err)
+	}
+	return token
+}
+
+func TestCreateRegistrationToken(t *testing.T) {
+	t.Parallel()
+
+	validData := jsonRegistrationToken{ID: "abc123", Token: "abc123"}
+	adminToken := adminTestToken(t)
+
+	testCases := []struct {
+		title   string
+		token   string
+		reqBody []byte
+		rtr     RegistrationTokenRepository
+
+		wantStatus int
+		wantResp   jsonRegistrationTokenResp
+	}{
+		{
+			title:   "200 - ok",
+			token:   adminToken,
+			reqBody: []byte(`{"token":"abc123"}`),
+			rtr: &mockRegistrationTokenRepo{
+				CreateRegistrationTokenFn: func(ctx context.Context, token jsonRegistrationToken) (jsonRegistrationToken, error) {
+					return validData, nil
+				},
+			},
+
+			wantStatus: http.StatusOK,
+			wantResp:   jsonRegistrationTokenResp{Data: validData},
+		},
+		{
+			title:   "400 - malformed token",
+			token:   adminToken,
+			reqBody: []byte(`{"token":"has spaces"}`),
+			rtr: &mockRegistrationTokenRepo{
+				CreateRegistrationTokenFn: func(ctx context.Context, token jsonRegistrationToken) (jsonRegistrationToken, error) {
+					return jsonRegistrationToken{}, ErrRegistrationTokenMalformed
+				},
+			},
+
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			title:      "400 - invalid request body",
+			token:      adminToken,
+			reqBody:    []byte(`{"token":}`),
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			title:      "403 - authenticated but not an admin",
+			token:      "Bearer " + mustIssueToken(t, adminTestAuth, "not_an_admin"),
+			reqBody:    []byte(`{"token":"abc123"}`),
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/admin/registration_tokens", bytes.NewBuffer(tc.reqBody))
+			if tc.token != "" {
+				r.Header.Set("Authorization", tc.token)
+			}
+
+			server := New(&mockRepo{}, WithAuthenticator(adminTestAuth), WithAdminUsers("admin_user"), WithRegistrationTokenRepository(tc.rtr))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gotResp jsonRegistrationTokenResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
+			}
+		})
+	}
+}
+
+func TestGetRegistrationToken(t *testing.T) {
+	t.Parallel()
+
+	validData := jsonRegistrationToken{ID: "abc123", Token: "abc123"}
+	adminToken := adminTestToken(t)
+
+	testCases := []struct {
+		title string
+		token string
+		rtr   RegistrationTokenRepository
+
+		wantStatus int
+		wantResp   jsonRegistrationTokenResp
+	}{
+		{
+			title: "200 - ok",
+			token: adminToken,
+			rtr: &mockRegistrationTokenRepo{
+				GetRegistrationTokenFn: func(ctx context.Context, id string) (jsonRegistrationToken, error) {
+					if id != "abc123" {
+						t.Errorf("unexpected id, got: %s want: abc123", id)
+					}
+					return validData, nil
+				},
+			},
+
+			wantStatus: http.StatusOK,
+			wantResp:   jsonRegistrationTokenResp{Data: validData},
+		},
+		{
+			title: "404 - not found",
+			token: adminToken,
+			rtr: &mockRegistrationTokenRepo{
+				GetRegistrationTokenFn: func(ctx context.Context, id string) (jsonRegistrationToken, error) {
+					return jsonRegistrationToken{}, ErrRegistrationTokenNotFound
+				},
+			},
+
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			title:      "403 - authenticated but not an admin",
+			token:      "Bearer " + mustIssueToken(t, adminTestAuth, "not_an_admin"),
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/admin/registration_tokens/abc123", nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", tc.token)
+			}
+
+			server := New(&mockRepo{}, WithAuthenticator(adminTestAuth), WithAdminUsers("admin_user"), WithRegistrationTokenRepository(tc.rtr))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gotResp jsonRegistrationTokenResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
+			}
+		})
+	}
+}
+
+func TestDeleteRegistrationToken(t *testing.T) {
+	t.Parallel()
+
+	adminToken := adminTestToken(t)
+
+	testCases := []struct {
+		title string
+		token string
+		rtr   RegistrationTokenRepository
+
+		wantStatus int
+	}{
+		{
+			title: "204 - ok",
+			token: adminToken,
+			rtr: &mockRegistrationTokenRepo{
+				DeleteRegistrationTokenFn: func(ctx context.Context, id string) error {
+					if id != "abc123" {
+						t.Errorf("unexpected id, got: %s want: abc123", id)
+					}
+					return nil
+				},
+			},
+
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			title: "404 - not found",
+			token: adminToken,
+			rtr: &mockRegistrationTokenRepo{
+				DeleteRegistrationTokenFn: func(ctx context.Context, id string) error {
+					return ErrRegistrationTokenNotFound
+				},
+			},
+
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			title:      "403 - authenticated but not an admin",
+			token:      "Bearer " + mustIssueToken(t, adminTestAuth, "not_an_admin"),
+			rtr:        &mockRegistrationTokenRepo{},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/api/admin/registration_tokens/abc123", nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", tc.token)
+			}
+
+			server := New(&mockRepo{}, WithAuthenticator(adminTestAuth), WithAdminUsers("admin_user"), WithRegistrationTokenRepository(tc.rtr))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+		})
+	}
+}
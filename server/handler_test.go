@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -33,7 +34,7 @@ func TestAirportSearch(t *testing.T) {
 			title:    "200 - ok",
 			endpoint: "/api/airports?search=SFO",
 			repository: &mockRepo{
-				GetAirportsFn: func(searchKey string) (jsonAirportSearchResp, error) {
+				GetAirportsFn: func(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
 					if searchKey != "SFO" {
 						t.Errorf("unexpected search key, got: %s want: %s", searchKey, "SFO")
 					}
@@ -49,7 +50,7 @@ func TestAirportSearch(t *testing.T) {
 			title:    "500 - error querying data",
 			endpoint: "/api/airports?search=boom",
 			repository: &mockRepo{
-				GetAirportsFn: func(searchKey string) (jsonAirportSearchResp, error) {
+				GetAirportsFn: func(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
 					return jsonAirportSearchResp{}, errors.New("boom")
 				},
 			},
@@ -114,7 +115,7 @@ func TestFlightSearch(t *testing.T) {
 			title:    "200 - ok",
 			endpoint: "/api/flightPaths/airport_a/airport_b?leave=12/15/2020",
 			repository: &mockRepo{
-				GetFlightPathsFn: func(from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+				GetFlightPathsFn: func(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
 					if from != "airport_a" {
 						t.Errorf("unexpected from param, got: %s want: %s", from, "airport_a")
 					}
@@ -138,7 +139,7 @@ func TestFlightSearch(t *testing.T) {
 			title:    "500 - invalid leave param",
 			endpoint: "/api/flightPaths/boom/boom?leave=",
 			repository: &mockRepo{
-				GetFlightPathsFn: func(from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+				GetFlightPathsFn: func(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
 					return jsonFlightSearchResp{}, nil
 				},
 			},
@@ -150,7 +151,7 @@ func TestFlightSearch(t *testing.T) {
 			title:    "500 - error querying data",
 			endpoint: "/api/flightPaths/airport_a/airport_b?leave=12/15/2020",
 			repository: &mockRepo{
-				GetFlightPathsFn: func(from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+				GetFlightPathsFn: func(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
 					return jsonFlightSearchResp{}, errors.New("boom")
 				},
 			},
@@ -210,7 +211,7 @@ func TestHotelSearch(t *testing.T) {
 			title:    "200 - ok with description",
 			endpoint: "/api/hotel/Four%20star/",
 			repository: &mockRepo{
-				GetHotelsFn: func(description, location string) (jsonHotelSearchResp, error) {
+				GetHotelsFn: func(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
 					if description != "Four star" {
 						t.Errorf("unexpected description param, got: %s want: %s", description, "Four star")
 					}
@@ -226,7 +227,7 @@ func TestHotelSearch(t *testing.T) {
 			title:    "200 - ok with description and location",
 			endpoint: "/api/hotel/Four%20star/London/",
 			repository: &mockRepo{
-				GetHotelsFn: func(description, location string) (jsonHotelSearchResp, error) {
+				GetHotelsFn: func(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
 					if description != "Four star" {
 						t.Errorf("unexpected description param, got: %s want: %s", description, "Four star")
 					}
@@ -245,7 +246,7 @@ func TestHotelSearch(t *testing.T) {
 			title:    "500 - error querying data",
 			endpoint: "/api/hotel/boom/",
 			repository: &mockRepo{
-				GetHotelsFn: func(description, location string) (jsonHotelSearchResp, error) {
+				GetHotelsFn: func(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
 					return jsonHotelSearchResp{}, errors.New("boom")
 				},
 			},
@@ -286,13 +287,7 @@ func TestHotelSearch(t *testing.T) {
 func TestUserLogin(t *testing.T) {
 	t.Parallel()
 
-	validJwtToken, err := createJwtToken("test_user")
-	if err != nil {
-		t.Fatal("error creating test jwt token:", err)
-	}
-
-	var validData jsonUserLoginResp
-	validData.Data.Token = validJwtToken
+	auth := NewHMACAuthenticator([]byte("test_secret"))
 
 	testCases := []struct {
 		title      string
@@ -300,33 +295,34 @@ func TestUserLogin(t *testing.T) {
 		reqBody    []byte
 		repository Repository
 
-		wantStatus int
-		wantResp   jsonUserLoginResp
+		wantStatus    int
+		wantResp      jsonUserLoginResp
+		wantTokenUser string
 	}{
 		{
 			title:    "200 - ok valid user",
 			endpoint: "/api/user/login",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				GetUserPasswordFn: func(username string) (string, error) {
+				VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
 					if username != "test_user" {
 						t.Errorf("unexpected username param, got: %s want: %s", username, "test_user")
 					}
 
-					return "test_passw", nil
+					return true, nil
 				},
 			},
 
-			wantStatus: http.StatusOK,
-			wantResp:   validData,
+			wantStatus:    http.StatusOK,
+			wantTokenUser: "test_user",
 		},
 		{
 			title:    "500 - error decoding request",
 			endpoint: "/api/user/login",
 			reqBody:  []byte(`{"user":}`),
 			repository: &mockRepo{
-				GetUserPasswordFn: func(username string) (string, error) {
-					return "", nil
+				VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, nil
 				},
 			},
 
@@ -338,8 +334,8 @@ func TestUserLogin(t *testing.T) {
 			endpoint: "/api/user/login",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				GetUserPasswordFn: func(username string) (string, error) {
-					return "", gocb.ErrDocumentNotFound
+				VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, gocb.ErrDocumentNotFound
 				},
 			},
 
@@ -351,8 +347,8 @@ func TestUserLogin(t *testing.T) {
 			endpoint: "/api/user/login",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				GetUserPasswordFn: func(username string) (string, error) {
-					return "", errors.New("boom")
+				VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, errors.New("boom")
 				},
 			},
 
@@ -364,8 +360,8 @@ func TestUserLogin(t *testing.T) {
 			endpoint: "/api/user/login",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				GetUserPasswordFn: func(username string) (string, error) {
-					return "boom", nil
+				VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, nil
 				},
 			},
 
@@ -382,7 +378,7 @@ func TestUserLogin(t *testing.T) {
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodPost, tc.endpoint, bytes.NewBuffer(tc.reqBody))
 
-			server := New(tc.repository)
+			server := New(tc.repository, WithAuthenticator(auth))
 			server.ServeHTTP(w, r)
 
 			// Check the status code is what we expect.
@@ -395,6 +391,18 @@ func TestUserLogin(t *testing.T) {
 			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
 				t.Fatal("error unmarshaling json:", err)
 			}
+
+			if tc.wantTokenUser != "" {
+				authedU, err := auth.Verify(gotResp.Data.Token)
+				if err != nil {
+					t.Fatalf("error verifying issued token: %v", err)
+				}
+				if authedU.Name != tc.wantTokenUser {
+					t.Errorf("unexpected token user, got: %s want: %s", authedU.Name, tc.wantTokenUser)
+				}
+				gotResp.Data.Token = ""
+			}
+
 			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
 				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
 			}
@@ -405,13 +413,7 @@ func TestUserLogin(t *testing.T) {
 func TestUserSignUp(t *testing.T) {
 	t.Parallel()
 
-	validJwtToken, err := createJwtToken("test_user")
-	if err != nil {
-		t.Fatal("error creating test jwt token:", err)
-	}
-
-	var validData jsonUserSignupResp
-	validData.Data.Token = validJwtToken
+	auth := NewHMACAuthenticator([]byte("test_secret"))
 
 	testCases := []struct {
 		title      string
@@ -419,15 +421,16 @@ func TestUserSignUp(t *testing.T) {
 		reqBody    []byte
 		repository Repository
 
-		wantStatus int
-		wantResp   jsonUserSignupResp
+		wantStatus    int
+		wantResp      jsonUserSignupResp
+		wantTokenUser string
 	}{
 		{
 			title:    "200 - ok valid user",
 			endpoint: "/api/user/signup",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				CreateUserFn: func(username, password string) error {
+				CreateUserFn: func(ctx context.Context, username, password string) error {
 					if username != "test_user" {
 						t.Errorf("unexpected username param, got: %s want: %s", username, "test_user")
 					}
@@ -439,15 +442,15 @@ func TestUserSignUp(t *testing.T) {
 				},
 			},
 
-			wantStatus: http.StatusOK,
-			wantResp:   validData,
+			wantStatus:    http.StatusOK,
+			wantTokenUser: "test_user",
 		},
 		{
 			title:    "500 - error decoding request",
 			endpoint: "/api/user/signup",
 			reqBody:  []byte(`{"user":}`),
 			repository: &mockRepo{
-				CreateUserFn: func(username, password string) error {
+				CreateUserFn: func(ctx context.Context, username, password string) error {
 					return nil
 				},
 			},
@@ -460,7 +463,7 @@ func TestUserSignUp(t *testing.T) {
 			endpoint: "/api/user/signup",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				CreateUserFn: func(username, password string) error {
+				CreateUserFn: func(ctx context.Context, username, password string) error {
 					return gocb.ErrDocumentExists
 				},
 			},
@@ -473,7 +476,7 @@ func TestUserSignUp(t *testing.T) {
 			endpoint: "/api/user/signup",
 			reqBody:  []byte(`{"user":"test_user","password":"test_passw"}`),
 			repository: &mockRepo{
-				CreateUserFn: func(username, password string) error {
+				CreateUserFn: func(ctx context.Context, username, password string) error {
 					return errors.New("boom")
 				},
 			},
@@ -491,7 +494,7 @@ func TestUserSignUp(t *testing.T) {
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest(http.MethodPost, tc.endpoint, bytes.NewBuffer(tc.reqBody))
 
-			server := New(tc.repository)
+			server := New(tc.repository, WithAuthenticator(auth))
 			server.ServeHTTP(w, r)
 
 			// Check the status code is what we expect.
@@ -504,6 +507,178 @@ func TestUserSignUp(t *testing.T) {
 			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
 				t.Fatal("error unmarshaling json:", err)
 			}
+
+			if tc.wantTokenUser != "" {
+				authedU, err := auth.Verify(gotResp.Data.Token)
+				if err != nil {
+					t.Fatalf("error verifying issued token: %v", err)
+				}
+				if authedU.Name != tc.wantTokenUser {
+					t.Errorf("unexpected token user, got: %s want: %s", authedU.Name, tc.wantTokenUser)
+				}
+				gotResp.Data.Token = ""
+			}
+
+			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
+			}
+		})
+	}
+}
+
+func TestUserSignUpWithRegistrationToken(t *testing.T) {
+	t.Parallel()
+
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+
+	testCases := []struct {
+		title      string
+		reqBody    []byte
+		repository Repository
+		rtr        RegistrationTokenRepository
+
+		wantStatus    int
+		wantResp      jsonUserSignupResp
+		wantTokenUser string
+	}{
+		{
+			title:   "200 - ok valid token",
+			reqBody: []byte(`{"user":"test_user","password":"test_passw","registration_token":"abc123"}`),
+			repository: &mockRepo{
+				CreateUserFn: func(ctx context.Context, username, password string) error {
+					return nil
+				},
+			},
+			rtr: &mockRegistrationTokenRepo{
+				ReserveRegistrationTokenFn: func(ctx context.Context, token string) (string, error) {
+					if token != "abc123" {
+						t.Errorf("unexpected token param, got: %s want: %s", token, "abc123")
+					}
+					return token, nil
+				},
+				CompleteRegistrationTokenFn: func(ctx context.Context, id string) error {
+					return nil
+				},
+			},
+
+			wantStatus:    http.StatusOK,
+			wantTokenUser: "test_user",
+		},
+		{
+			title:   "401 - malformed token",
+			reqBody: []byte(`{"user":"test_user","password":"test_passw","registration_token":"!!!"}`),
+			rtr: &mockRegistrationTokenRepo{
+				ReserveRegistrationTokenFn: func(ctx context.Context, token string) (string, error) {
+					return "", ErrRegistrationTokenInvalid
+				},
+			},
+
+			wantStatus: http.StatusUnauthorized,
+			wantResp:   jsonUserSignupResp{},
+		},
+		{
+			title:   "401 - expired token",
+			reqBody: []byte(`{"user":"test_user","password":"test_passw","registration_token":"expired"}`),
+			rtr: &mockRegistrationTokenRepo{
+				ReserveRegistrationTokenFn: func(ctx context.Context, token string) (string, error) {
+					return "", ErrRegistrationTokenInvalid
+				},
+			},
+
+			wantStatus: http.StatusUnauthorized,
+			wantResp:   jsonUserSignupResp{},
+		},
+		{
+			title:   "401 - exhausted token",
+			reqBody: []byte(`{"user":"test_user","password":"test_passw","registration_token":"exhausted"}`),
+			rtr: &mockRegistrationTokenRepo{
+				ReserveRegistrationTokenFn: func(ctx context.Context, token string) (string, error) {
+					return "", ErrRegistrationTokenInvalid
+				},
+			},
+
+			wantStatus: http.StatusUnauthorized,
+			wantResp:   jsonUserSignupResp{},
+		},
+		{
+			title:   "200 - ok after a concurrent CAS retry",
+			reqBody: []byte(`{"user":"test_user","password":"test_passw","registration_token":"contended"}`),
+			repository: &mockRepo{
+				CreateUserFn: func(ctx context.Context, username, password string) error {
+					return nil
+				},
+			},
+			rtr: &mockRegistrationTokenRepo{
+				// Models ReserveRegistrationToken's internal CAS-retry loop
+				// winning against a racing reservation: the caller only
+				// ever sees the eventual success.
+				ReserveRegistrationTokenFn: func(ctx context.Context, token string) (string, error) {
+					return token, nil
+				},
+				CompleteRegistrationTokenFn: func(ctx context.Context, id string) error {
+					return nil
+				},
+			},
+
+			wantStatus:    http.StatusOK,
+			wantTokenUser: "test_user",
+		},
+		{
+			title:   "409 - token released after user already exists",
+			reqBody: []byte(`{"user":"test_user","password":"test_passw","registration_token":"abc123"}`),
+			repository: &mockRepo{
+				CreateUserFn: func(ctx context.Context, username, password string) error {
+					return gocb.ErrDocumentExists
+				},
+			},
+			rtr: &mockRegistrationTokenRepo{
+				ReserveRegistrationTokenFn: func(ctx context.Context, token string) (string, error) {
+					return token, nil
+				},
+				ReleaseRegistrationTokenFn: func(ctx context.Context, id string) error {
+					if id != "abc123" {
+						t.Errorf("unexpected released token id, got: %s want: %s", id, "abc123")
+					}
+					return nil
+				},
+			},
+
+			wantStatus: http.StatusConflict,
+			wantResp:   jsonUserSignupResp{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/user/signup", bytes.NewBuffer(tc.reqBody))
+
+			server := New(tc.repository, WithAuthenticator(auth), WithRegistrationRequiresToken(true), WithRegistrationTokenRepository(tc.rtr))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			var gotResp jsonUserSignupResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+
+			if tc.wantTokenUser != "" {
+				authedU, err := auth.Verify(gotResp.Data.Token)
+				if err != nil {
+					t.Fatalf("error verifying issued token: %v", err)
+				}
+				if authedU.Name != tc.wantTokenUser {
+					t.Errorf("unexpected token user, got: %s want: %s", authedU.Name, tc.wantTokenUser)
+				}
+				gotResp.Data.Token = ""
+			}
+
 			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
 				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
 			}
@@ -521,12 +696,14 @@ func TestUserFlights(t *testing.T) {
 		},
 	}
 
-	validJwtToken, err := createJwtToken("test_user")
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+
+	validJwtToken, err := auth.Issue("test_user")
 	if err != nil {
 		t.Fatal("error creating test jwt token:", err)
 	}
 
-	invalidJwtToken, err := createJwtToken("")
+	invalidJwtToken, err := auth.Issue("")
 	if err != nil {
 		t.Fatal("error creating test jwt token:", err)
 	}
@@ -545,7 +722,7 @@ func TestUserFlights(t *testing.T) {
 			endpoint: "/api/user/test_user/flights",
 			token:    "Bearer " + validJwtToken,
 			repository: &mockRepo{
-				GetUserFlightsFn: func(username string) (jsonUserFlightsResp, error) {
+				GetUserFlightsFn: func(ctx context.Context, username string) (jsonUserFlightsResp, error) {
 					if username != "test_user" {
 						t.Errorf("unexpected username param, got: %s want: %s", username, "test_user")
 					}
@@ -562,7 +739,7 @@ func TestUserFlights(t *testing.T) {
 			endpoint: "/api/user/test_user/flights",
 			token:    "boom",
 			repository: &mockRepo{
-				GetUserFlightsFn: func(username string) (jsonUserFlightsResp, error) {
+				GetUserFlightsFn: func(ctx context.Context, username string) (jsonUserFlightsResp, error) {
 					return jsonUserFlightsResp{}, nil
 				},
 			},
@@ -575,7 +752,7 @@ func TestUserFlights(t *testing.T) {
 			endpoint: "/api/user/test_user/flights",
 			token:    "Bearer boom",
 			repository: &mockRepo{
-				GetUserFlightsFn: func(username string) (jsonUserFlightsResp, error) {
+				GetUserFlightsFn: func(ctx context.Context, username string) (jsonUserFlightsResp, error) {
 					return jsonUserFlightsResp{}, nil
 				},
 			},
@@ -588,7 +765,7 @@ func TestUserFlights(t *testing.T) {
 			endpoint: "/api/user/boom/flights",
 			token:    "Bearer " + invalidJwtToken,
 			repository: &mockRepo{
-				GetUserFlightsFn: func(username string) (jsonUserFlightsResp, error) {
+				GetUserFlightsFn: func(ctx context.Context, username string) (jsonUserFlightsResp, error) {
 					return jsonUserFlightsResp{}, nil
 				},
 			},
@@ -601,7 +778,7 @@ func TestUserFlights(t *testing.T) {
 			endpoint: "/api/user/test_user/flights",
 			token:    "Bearer " + validJwtToken,
 			repository: &mockRepo{
-				GetUserFlightsFn: func(username string) (jsonUserFlightsResp, error) {
+				GetUserFlightsFn: func(ctx context.Context, username string) (jsonUserFlightsResp, error) {
 					return jsonUserFlightsResp{}, errors.New("boom")
 				},
 			},
@@ -620,7 +797,7 @@ func TestUserFlights(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, tc.endpoint, nil)
 			r.Header.Set("Authorization", tc.token)
 
-			server := New(tc.repository)
+			server := New(tc.repository, WithAuthenticator(auth))
 			server.ServeHTTP(w, r)
 
 			// Check the status code is what we expect.
@@ -649,12 +826,14 @@ func TestUserBookFlight(t *testing.T) {
 	}
 	validData.Data.Added = flights
 
-	validJwtToken, err := createJwtToken("test_user")
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+
+	validJwtToken, err := auth.Issue("test_user")
 	if err != nil {
 		t.Fatal("error creating test jwt token:", err)
 	}
 
-	invalidJwtToken, err := createJwtToken("")
+	invalidJwtToken, err := auth.Issue("")
 	if err != nil {
 		t.Fatal("error creating test jwt token:", err)
 	}
@@ -675,7 +854,7 @@ func TestUserBookFlight(t *testing.T) {
 			token:    "Bearer " + validJwtToken,
 			reqBody:  []byte(`{"flights":[{"name":"US Airways","flight":"US229","sourceairport":"SFO","destinationairport":"LAX","price":158.38}]}`),
 			repository: &mockRepo{
-				UpdateUserFlightsFn: func(username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+				UpdateUserFlightsFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
 					if username != "test_user" {
 						t.Errorf("unexpected username param, got: %s want: %s", username, "test_user")
 					}
@@ -695,7 +874,7 @@ func TestUserBookFlight(t *testing.T) {
 			endpoint: "/api/user/test_user/flights",
 			token:    "boom",
 			repository: &mockRepo{
-				UpdateUserFlightsFn: func(username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+				UpdateUserFlightsFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
 					return jsonUserBookFlightResp{}, nil
 				},
 			},
@@ -708,7 +887,7 @@ func TestUserBookFlight(t *testing.T) {
 			endpoint: "/api/user/test_user/flights",
 			token:    "Bearer boom",
 			repository: &mockRepo{
-				UpdateUserFlightsFn: func(username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+				UpdateUserFlightsFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
 					return jsonUserBookFlightResp{}, nil
 				},
 			},
@@ -721,7 +900,7 @@ func TestUserBookFlight(t *testing.T) {
 			endpoint: "/api/user/boom/flights",
 			token:    "Bearer " + invalidJwtToken,
 			repository: &mockRepo{
-				UpdateUserFlightsFn: func(username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+				UpdateUserFlightsFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
 					return jsonUserBookFlightResp{}, nil
 				},
 			},
@@ -735,7 +914,7 @@ func TestUserBookFlight(t *testing.T) {
 			token:    "Bearer " + validJwtToken,
 			reqBody:  []byte(`{"boom":}`),
 			repository: &mockRepo{
-				UpdateUserFlightsFn: func(username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+				UpdateUserFlightsFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
 					return jsonUserBookFlightResp{}, nil
 				},
 			},
@@ -749,7 +928,7 @@ func TestUserBookFlight(t *testing.T) {
 			reqBody:  []byte(`{"flights":[{"name":"US Airways","flight":"US229","sourceairport":"SFO","destinationairport":"LAX","price":158.38}]}`),
 			token:    "Bearer " + validJwtToken,
 			repository: &mockRepo{
-				UpdateUserFlightsFn: func(username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+				UpdateUserFlightsFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
 					return jsonUserBookFlightResp{}, errors.New("boom")
 				},
 			},
@@ -768,7 +947,7 @@ func TestUserBookFlight(t *testing.T) {
 			r := httptest.NewRequest(http.MethodPost, tc.endpoint, bytes.NewBuffer(tc.reqBody))
 			r.Header.Set("Authorization", tc.token)
 
-			server := New(tc.repository)
+			server := New(tc.repository, WithAuthenticator(auth))
 			server.ServeHTTP(w, r)
 
 			// Check the status code is what we expect.
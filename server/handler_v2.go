@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// decodeReqOrFailV2 decodes req's JSON body into data, writing a v2-style
+// error response on failure.
+func decodeReqOrFailV2(w http.ResponseWriter, req *http.Request, data interface{}) error {
+	if err := json.NewDecoder(req.Body).Decode(data); err != nil {
+		setRequestErrorReason(req.Context(), "decode_error")
+		writeJsonErrorV2(w, 400, "invalid_request_body", err.Error())
+		return err
+	}
+	return nil
+}
+
+// writeJsonErrorV2 writes the v2 API's machine-readable error envelope,
+// {"error":{"code":"...","message":"..."}}, in place of the v1 API's bare
+// {"failure":"..."}.
+func writeJsonErrorV2(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encodeRespOrFail(w, jsonErrorResp{Error: jsonErrorDetail{Code: code, Message: message}})
+}
+
+// GET /api/v2/flights?from=FAA&to=FAA&departure=RFC3339&departure_radius=minutes&count=N&price_max=...&page_token=...
+func (s *Server) FlightSearchV2(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
+	q := req.URL.Query()
+
+	departure, err := time.Parse(time.RFC3339, q.Get("departure"))
+	if err != nil {
+		writeJsonErrorV2(w, 400, "invalid_departure", "departure must be an RFC3339 timestamp")
+		return
+	}
+
+	var departureRadius time.Duration
+	if v := q.Get("departure_radius"); v != "" {
+		minutes, err := strconv.Atoi(v)
+		if err != nil || minutes < 0 {
+			writeJsonErrorV2(w, 400, "invalid_departure_radius", "departure_radius must be a non-negative integer number of minutes")
+			return
+		}
+		departureRadius = time.Duration(minutes) * time.Minute
+	}
+
+	count, ok := parseOptionalCount(w, q.Get("count"))
+	if !ok {
+		return
+	}
+
+	var priceMax float64
+	if v := q.Get("price_max"); v != "" {
+		priceMax, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			writeJsonErrorV2(w, 400, "invalid_price_max", "price_max must be a number")
+			return
+		}
+	}
+
+	respData, err := s.db.SearchFlightsV2(ctx, flightSearchV2Params{
+		From:            q.Get("from"),
+		To:              q.Get("to"),
+		Departure:       departure,
+		DepartureRadius: departureRadius,
+		PriceMax:        priceMax,
+		Count:           count,
+		PageToken:       q.Get("page_token"),
+	})
+	if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, respData)
+}
+
+// GET /api/v2/hotels?q=...&location=...&count=N&page_token=...
+func (s *Server) HotelSearchV2(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
+	q := req.URL.Query()
+
+	count, ok := parseOptionalCount(w, q.Get("count"))
+	if !ok {
+		return
+	}
+
+	respData, err := s.db.SearchHotelsV2(ctx, hotelSearchV2Params{
+		Query:     q.Get("q"),
+		Location:  q.Get("location"),
+		Count:     count,
+		PageToken: q.Get("page_token"),
+	})
+	if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, respData)
+}
+
+// parseOptionalCount parses the "count" query parameter, writing a v2
+// error response and returning ok=false if it's present but invalid.
+func parseOptionalCount(w http.ResponseWriter, v string) (count int, ok bool) {
+	if v == "" {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		writeJsonErrorV2(w, 400, "invalid_count", "count must be a positive integer")
+		return 0, false
+	}
+
+	return n, true
+}
+
+// POST /api/v2/bookings
+type jsonCreateBookingReq struct {
+	Flights []jsonBookedFlight `json:"flights"`
+}
+
+type jsonBookingResp struct {
+	Data    jsonBooking `json:"data"`
+	Context jsonContext `json:"context"`
+}
+
+func (s *Server) CreateBooking(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
+	var authUser authedUser
+	if !s.decodeAuthUserOrFail(w, req, &authUser) {
+		return
+	}
+
+	var reqData jsonCreateBookingReq
+	if err := decodeReqOrFailV2(w, req, &reqData); err != nil {
+		return
+	}
+
+	if len(reqData.Flights) == 0 {
+		writeJsonErrorV2(w, 400, "no_flights", "a booking requires at least one flight")
+		return
+	}
+
+	if s.Bookings == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "bookings are not configured on this server")
+		return
+	}
+
+	booking, err := s.Bookings.CreateBooking(ctx, authUser.Name, reqData.Flights)
+	if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonBookingResp{Data: booking})
+}
+
+// GET /api/v2/bookings/{id}
+func (s *Server) GetBookingV2(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
+	var authUser authedUser
+	if !s.decodeAuthUserOrFail(w, req, &authUser) {
+		return
+	}
+
+	if s.Bookings == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "bookings are not configured on this server")
+		return
+	}
+
+	id := mux.Vars(req)["id"]
+	booking, err := s.Bookings.GetBooking(ctx, id)
+	if errors.Is(err, ErrBookingNotFound) {
+		writeJsonErrorV2(w, 404, "booking_not_found", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	if booking.User != authUser.Name {
+		writeJsonErrorV2(w, 404, "booking_not_found", ErrBookingNotFound.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonBookingResp{Data: booking})
+}
+
+// PATCH /api/v2/bookings/{id}/status
+type jsonUpdateBookingStatusReq struct {
+	Status BookingStatus `json:"status"`
+}
+
+func (s *Server) UpdateBookingStatusV2(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), s.RequestTimeout)
+	defer cancel()
+
+	var authUser authedUser
+	if !s.decodeAuthUserOrFail(w, req, &authUser) {
+		return
+	}
+
+	if s.Bookings == nil {
+		writeJsonErrorV2(w, 500, "internal_error", "bookings are not configured on this server")
+		return
+	}
+
+	var reqData jsonUpdateBookingStatusReq
+	if err := decodeReqOrFailV2(w, req, &reqData); err != nil {
+		return
+	}
+
+	id := mux.Vars(req)["id"]
+
+	existing, err := s.Bookings.GetBooking(ctx, id)
+	if errors.Is(err, ErrBookingNotFound) {
+		writeJsonErrorV2(w, 404, "booking_not_found", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+	if existing.User != authUser.Name {
+		writeJsonErrorV2(w, 404, "booking_not_found", ErrBookingNotFound.Error())
+		return
+	}
+
+	booking, err := s.Bookings.UpdateBookingStatus(ctx, id, reqData.Status)
+	if errors.Is(err, ErrBookingNotFound) {
+		writeJsonErrorV2(w, 404, "booking_not_found", err.Error())
+		return
+	} else if errors.Is(err, ErrInvalidBookingStatus) {
+		writeJsonErrorV2(w, 409, "invalid_status_transition", err.Error())
+		return
+	} else if err != nil {
+		writeJsonErrorV2(w, statusForErr(err), "internal_error", err.Error())
+		return
+	}
+
+	encodeRespOrFail(w, jsonBookingResp{Data: booking})
+}
@@ -0,0 +1,395 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlightSearchV2(t *testing.T) {
+	t.Parallel()
+
+	validData := jsonFlightSearchV2Resp{
+		Context: jsonContext{"test"},
+		Data:    []jsonFlight{{Name: "US Airways", Flight: "US229"}},
+	}
+
+	testCases := []struct {
+		title      string
+		endpoint   string
+		repository Repository
+
+		wantStatus int
+		wantResp   jsonFlightSearchV2Resp
+	}{
+		{
+			title:    "200 - ok",
+			endpoint: "/api/v2/flights?from=SFO&to=LAX&departure=2026-07-26T10:00:00Z&count=5",
+			repository: &mockRepo{
+				SearchFlightsV2Fn: func(ctx context.Context, p flightSearchV2Params) (jsonFlightSearchV2Resp, error) {
+					if p.From != "SFO" || p.To != "LAX" {
+						t.Errorf("unexpected from/to, got: %s/%s want: SFO/LAX", p.From, p.To)
+					}
+					if p.Count != 5 {
+						t.Errorf("unexpected count, got: %d want: 5", p.Count)
+					}
+
+					return validData, nil
+				},
+			},
+
+			wantStatus: http.StatusOK,
+			wantResp:   validData,
+		},
+		{
+			title:      "400 - missing departure",
+			endpoint:   "/api/v2/flights?from=SFO&to=LAX",
+			repository: &mockRepo{},
+
+			wantStatus: http.StatusBadRequest,
+			wantResp:   jsonFlightSearchV2Resp{},
+		},
+		{
+			title:    "500 - error querying data",
+			endpoint: "/api/v2/flights?from=SFO&to=LAX&departure=2026-07-26T10:00:00Z",
+			repository: &mockRepo{
+				SearchFlightsV2Fn: func(ctx context.Context, p flightSearchV2Params) (jsonFlightSearchV2Resp, error) {
+					return jsonFlightSearchV2Resp{}, errors.New("boom")
+				},
+			},
+
+			wantStatus: http.StatusInternalServerError,
+			wantResp:   jsonFlightSearchV2Resp{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tc.endpoint, nil)
+
+			server := New(tc.repository)
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gotResp jsonFlightSearchV2Resp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
+			}
+		})
+	}
+}
+
+func TestCreateBooking(t *testing.T) {
+	t.Parallel()
+
+	flights := []jsonBookedFlight{
+		{Name: "US Airways", Flight: "US229", SourceAirport: "SFO", DestinationAirport: "LAX", Price: 158.38},
+	}
+	validData := jsonBooking{ID: "booking-1", User: "test_user", Status: BookingWaitingConfirmation, Flights: flights}
+
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+	validJwtToken, err := auth.Issue("test_user")
+	if err != nil {
+		t.Fatal("error creating test jwt token:", err)
+	}
+
+	testCases := []struct {
+		title        string
+		token        string
+		reqBody      []byte
+		repository   Repository
+		bookingRepo  BookingRepository
+		wantStatus   int
+		wantBookResp jsonBookingResp
+	}{
+		{
+			title:   "200 - ok",
+			token:   "Bearer " + validJwtToken,
+			reqBody: []byte(`{"flights":[{"name":"US Airways","flight":"US229","sourceairport":"SFO","destinationairport":"LAX","price":158.38}]}`),
+			bookingRepo: &mockBookingRepo{
+				CreateBookingFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonBooking, error) {
+					if username != "test_user" {
+						t.Errorf("unexpected username, got: %s want: test_user", username)
+					}
+					return validData, nil
+				},
+			},
+
+			wantStatus:   http.StatusOK,
+			wantBookResp: jsonBookingResp{Data: validData},
+		},
+		{
+			title:   "400 - no flights",
+			token:   "Bearer " + validJwtToken,
+			reqBody: []byte(`{"flights":[]}`),
+			bookingRepo: &mockBookingRepo{
+				CreateBookingFn: func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonBooking, error) {
+					return jsonBooking{}, nil
+				},
+			},
+
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			title:       "400 - bad auth header",
+			token:       "boom",
+			reqBody:     []byte(`{"flights":[]}`),
+			bookingRepo: &mockBookingRepo{},
+
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/api/v2/bookings", bytes.NewBuffer(tc.reqBody))
+			r.Header.Set("Authorization", tc.token)
+
+			server := New(&mockRepo{}, WithAuthenticator(auth), WithBookingRepository(tc.bookingRepo))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gotResp jsonBookingResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+			if diff := cmp.Diff(gotResp, tc.wantBookResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantBookResp)
+			}
+		})
+	}
+}
+
+func TestGetBookingV2(t *testing.T) {
+	t.Parallel()
+
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+	validJwtToken, err := auth.Issue("test_user")
+	if err != nil {
+		t.Fatal("error creating test jwt token:", err)
+	}
+
+	testCases := []struct {
+		title       string
+		token       string
+		bookingRepo BookingRepository
+
+		wantStatus int
+		wantResp   jsonBookingResp
+	}{
+		{
+			title: "200 - ok",
+			token: "Bearer " + validJwtToken,
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{ID: "booking-1", User: "test_user", Status: BookingWaitingConfirmation}, nil
+				},
+			},
+
+			wantStatus: http.StatusOK,
+			wantResp:   jsonBookingResp{Data: jsonBooking{ID: "booking-1", User: "test_user", Status: BookingWaitingConfirmation}},
+		},
+		{
+			title: "404 - owned by a different user",
+			token: "Bearer " + validJwtToken,
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{ID: "booking-1", User: "someone_else", Status: BookingWaitingConfirmation}, nil
+				},
+			},
+
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			title: "404 - not found",
+			token: "Bearer " + validJwtToken,
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{}, ErrBookingNotFound
+				},
+			},
+
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			title:       "400 - bad auth header",
+			token:       "boom",
+			bookingRepo: &mockBookingRepo{},
+
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/v2/bookings/booking-1", nil)
+			r.Header.Set("Authorization", tc.token)
+
+			server := New(&mockRepo{}, WithAuthenticator(auth), WithBookingRepository(tc.bookingRepo))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+
+			var gotResp jsonBookingResp
+			if err := json.Unmarshal(w.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal("error unmarshaling json:", err)
+			}
+			if diff := cmp.Diff(gotResp, tc.wantResp); diff != "" {
+				t.Errorf("invalid response body: \ngot: %#v, \nwant: %#v", gotResp, tc.wantResp)
+			}
+		})
+	}
+}
+
+func TestUpdateBookingStatusV2(t *testing.T) {
+	t.Parallel()
+
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+	validJwtToken, err := auth.Issue("test_user")
+	if err != nil {
+		t.Fatal("error creating test jwt token:", err)
+	}
+
+	testCases := []struct {
+		title       string
+		token       string
+		endpoint    string
+		reqBody     []byte
+		bookingRepo BookingRepository
+
+		wantStatus int
+	}{
+		{
+			title:    "200 - ok",
+			token:    "Bearer " + validJwtToken,
+			endpoint: "/api/v2/bookings/booking-1/status",
+			reqBody:  []byte(`{"status":"CONFIRMED"}`),
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{ID: id, User: "test_user", Status: BookingWaitingConfirmation}, nil
+				},
+				UpdateBookingStatusFn: func(ctx context.Context, id string, status BookingStatus) (jsonBooking, error) {
+					if id != "booking-1" {
+						t.Errorf("unexpected id, got: %s want: booking-1", id)
+					}
+					if status != BookingConfirmed {
+						t.Errorf("unexpected status, got: %s want: %s", status, BookingConfirmed)
+					}
+					return jsonBooking{ID: id, Status: status}, nil
+				},
+			},
+
+			wantStatus: http.StatusOK,
+		},
+		{
+			title:    "409 - invalid transition",
+			token:    "Bearer " + validJwtToken,
+			endpoint: "/api/v2/bookings/booking-1/status",
+			reqBody:  []byte(`{"status":"COMPLETED"}`),
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{ID: id, User: "test_user", Status: BookingWaitingConfirmation}, nil
+				},
+				UpdateBookingStatusFn: func(ctx context.Context, id string, status BookingStatus) (jsonBooking, error) {
+					return jsonBooking{}, ErrInvalidBookingStatus
+				},
+			},
+
+			wantStatus: http.StatusConflict,
+		},
+		{
+			title:    "404 - booking not found",
+			token:    "Bearer " + validJwtToken,
+			endpoint: "/api/v2/bookings/boom/status",
+			reqBody:  []byte(`{"status":"CONFIRMED"}`),
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{}, ErrBookingNotFound
+				},
+			},
+
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			title:    "404 - owned by a different user",
+			token:    "Bearer " + validJwtToken,
+			endpoint: "/api/v2/bookings/booking-1/status",
+			reqBody:  []byte(`{"status":"CONFIRMED"}`),
+			bookingRepo: &mockBookingRepo{
+				GetBookingFn: func(ctx context.Context, id string) (jsonBooking, error) {
+					return jsonBooking{ID: "booking-1", User: "someone_else", Status: BookingWaitingConfirmation}, nil
+				},
+			},
+
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			title:       "400 - bad auth header",
+			token:       "boom",
+			endpoint:    "/api/v2/bookings/booking-1/status",
+			reqBody:     []byte(`{"status":"CONFIRMED"}`),
+			bookingRepo: &mockBookingRepo{},
+
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, tc.endpoint, bytes.NewBuffer(tc.reqBody))
+			r.Header.Set("Authorization", tc.token)
+
+			server := New(&mockRepo{}, WithAuthenticator(auth), WithBookingRepository(tc.bookingRepo))
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantStatus {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/couchbaselabs/try-cb-golang/health"
+)
+
+// Healthz is a pure process-liveness probe: once the HTTP server can
+// serve it, it always returns 200.
+func (s *Server) Healthz(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// Readyz reports whether the server's dependencies (Couchbase's Query,
+// Search and KV services) are reachable, so an orchestrator can hold
+// traffic back from a pod that's up but not yet able to serve requests.
+func (s *Server) Readyz(w http.ResponseWriter, req *http.Request) {
+	report := health.Report{Status: health.StatusUp}
+	if s.Readiness != nil {
+		report = s.Readiness.Check(req.Context())
+	}
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encodeRespOrFail(w, report)
+}
+
+// ReadinessCheckerFromEnv builds the Checker to pass to
+// WithReadinessChecker for a CBRepository-backed db, refreshing on
+// READINESS_CHECK_INTERVAL (default 5s), each check bounded by
+// READINESS_CHECK_TIMEOUT (default 2s). A db that doesn't expose a
+// Couchbase cluster (e.g. a test mock) gets a Checker that always
+// reports ready.
+func ReadinessCheckerFromEnv(db Repository) health.Checker {
+	type clusterProvider interface {
+		Cluster() *gocb.Cluster
+	}
+
+	cp, ok := db.(clusterProvider)
+	if !ok {
+		return health.CheckerFunc(func(ctx context.Context) health.Report {
+			return health.Report{Status: health.StatusUp}
+		})
+	}
+
+	interval := getEnvDuration("READINESS_CHECK_INTERVAL", 5*time.Second)
+	timeout := getEnvDuration("READINESS_CHECK_TIMEOUT", 2*time.Second)
+
+	return health.NewCache(health.NewCouchbaseChecker(cp.Cluster(), timeout), interval, timeout)
+}
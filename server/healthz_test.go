@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couchbaselabs/try-cb-golang/health"
+)
+
+func TestHealthz(t *testing.T) {
+	t.Parallel()
+
+	server := New(&mockRepo{})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+
+	server.ServeHTTP(w, r)
+
+	if status := w.Code; status != http.StatusOK {
+		t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, http.StatusOK)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title    string
+		checker  health.Checker
+		wantCode int
+	}{
+		{
+			title:    "200 - no checker configured",
+			checker:  nil,
+			wantCode: http.StatusOK,
+		},
+		{
+			title: "200 - dependency up",
+			checker: health.CheckerFunc(func(ctx context.Context) health.Report {
+				return health.Report{Status: health.StatusUp}
+			}),
+			wantCode: http.StatusOK,
+		},
+		{
+			title: "503 - dependency down",
+			checker: health.CheckerFunc(func(ctx context.Context) health.Report {
+				return health.Report{Status: health.StatusDown}
+			}),
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			var opts []Option
+			if tc.checker != nil {
+				opts = append(opts, WithReadinessChecker(tc.checker))
+			}
+
+			server := New(&mockRepo{}, opts...)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+			server.ServeHTTP(w, r)
+
+			if status := w.Code; status != tc.wantCode {
+				t.Errorf("invalid status code: \ngot: %#v, \nwant: %#v", status, tc.wantCode)
+			}
+		})
+	}
+}
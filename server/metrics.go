@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// couchbaseQueryDuration is recorded by CBRepository methods around their
+// N1QL/FTS/KV calls. It stays on the global default registry: CBRepository
+// is constructed independently of Server (often before a Server exists at
+// all), so it has no per-Server registry to record against.
+var couchbaseQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "couchbase_query_duration_seconds",
+	Help:    "Couchbase operation latency in seconds, labeled by service.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"service"})
+
+// serverMetrics holds the collectors a Server records to. It's built
+// against a caller-supplied prometheus.Registerer (a fresh registry per
+// Server by default, overridable via WithMetricsRegistry) so tests can assert
+// on an isolated set of series instead of fighting over package-level
+// globals shared by every Server in the process.
+type serverMetrics struct {
+	httpRequestsTotal     *prometheus.CounterVec
+	httpRequestDuration   *prometheus.HistogramVec
+	repoOperationDuration *prometheus.HistogramVec
+	repoOperationErrors   *prometheus.CounterVec
+}
+
+func newServerMetrics(reg prometheus.Registerer) *serverMetrics {
+	f := promauto.With(reg)
+
+	return &serverMetrics{
+		httpRequestsTotal: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests, labeled by route, method and status.",
+		}, []string{"route", "method", "status"}),
+
+		httpRequestDuration: f.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by route and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+
+		// repoOperationDuration/repoOperationErrors are recorded by
+		// instrumentedRepository, a thin wrapper around the Repository
+		// interface installed by New, rather than by CBRepository itself:
+		// they measure the public Repository contract a handler sees,
+		// not the lower-level Couchbase calls couchbaseQueryDuration
+		// already covers.
+		repoOperationDuration: f.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repo_operation_duration_seconds",
+			Help:    "Repository method latency in seconds, labeled by operation.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"op"}),
+
+		repoOperationErrors: f.NewCounterVec(prometheus.CounterOpts{
+			Name: "repo_operation_errors_total",
+			Help: "Total Repository method errors, labeled by operation and error kind.",
+		}, []string{"op", "kind"}),
+	}
+}
+
+// metricsMiddleware records http_requests_total and
+// http_request_duration_seconds for every request. It must run inside
+// the router (via Router.Use) so mux.CurrentRoute is already populated,
+// letting it label by the matched route template rather than the raw,
+// high-cardinality path.
+func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rec := &statusRecordingWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, req)
+
+		route := routeLabel(req)
+		s.metrics.httpRequestsTotal.WithLabelValues(route, req.Method, strconv.Itoa(rec.status)).Inc()
+		s.metrics.httpRequestDuration.WithLabelValues(route, req.Method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecordingWriter captures the status code and response size a
+// handler wrote, since http.ResponseWriter doesn't expose either after
+// the fact.
+type statusRecordingWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusRecordingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecordingWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// observeCouchbaseDuration records how long a Couchbase operation against
+// service took, measured from start.
+func observeCouchbaseDuration(service string, start time.Time) {
+	couchbaseQueryDuration.WithLabelValues(service).Observe(time.Since(start).Seconds())
+}
+
+// logCouchbaseQuery logs the N1QL/FTS query identifier a CBRepository method
+// is about to issue, tagged with the request's correlation ID so a slow or
+// failing query can be traced back to the request that caused it. It logs
+// through the default slog logger rather than Server.Logger: CBRepository is
+// constructed independently of any Server, so it has no per-instance logger
+// to call through, the same reason couchbaseQueryDuration stays a package
+// global instead of living on serverMetrics.
+func logCouchbaseQuery(ctx context.Context, service, query string) {
+	slog.Default().DebugContext(ctx, "couchbase query",
+		"request_id", requestIDFromContext(ctx),
+		"service", service,
+		"query", query,
+	)
+}
+
+func routeLabel(req *http.Request) string {
+	if route := mux.CurrentRoute(req); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return "unmatched"
+}
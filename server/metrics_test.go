@@ -0,0 +1,123 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+)
+
+// counterValue returns the sum of a CounterVec's series matching
+// labelValues (in the same order as its declared label names), or 0 if
+// none match.
+func counterValue(t *testing.T, c *prometheus.CounterVec, labelValues ...string) float64 {
+	t.Helper()
+
+	m := &io_prometheus_client.Metric{}
+	if err := c.WithLabelValues(labelValues...).Write(m); err != nil {
+		t.Fatalf("error reading counter: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+// histogramSampleCount returns how many observations a HistogramVec's
+// series matching labelValues has recorded.
+func histogramSampleCount(t *testing.T, h *prometheus.HistogramVec, labelValues ...string) uint64 {
+	t.Helper()
+
+	m := &io_prometheus_client.Metric{}
+	if err := h.WithLabelValues(labelValues...).(prometheus.Histogram).Write(m); err != nil {
+		t.Fatalf("error reading histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestMetricsHTTPRequestLabels(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	server := New(&mockRepo{
+		GetAirportsFn: func(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
+			return jsonAirportSearchResp{}, nil
+		},
+	}, WithMetricsRegistry(reg))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/airports?search=SFO", nil)
+	server.ServeHTTP(w, r)
+
+	if got := counterValue(t, server.metrics.httpRequestsTotal, "/api/airports", http.MethodGet, "200"); got != 1 {
+		t.Errorf("unexpected http_requests_total{route=/api/airports,method=GET,status=200}, got: %v want: 1", got)
+	}
+	if got := histogramSampleCount(t, server.metrics.httpRequestDuration, "/api/airports", http.MethodGet); got != 1 {
+		t.Errorf("unexpected http_request_duration_seconds sample count, got: %d want: 1", got)
+	}
+}
+
+func TestMetricsHTTPRequestLabelsAcrossEndpoints(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	auth := NewHMACAuthenticator([]byte("test_secret"))
+	server := New(&mockRepo{
+		GetFlightPathsFn: func(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+			return jsonFlightSearchResp{}, errors.New("boom")
+		},
+		GetHotelsFn: func(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
+			return jsonHotelSearchResp{}, nil
+		},
+		VerifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+			return false, gocb.ErrDocumentNotFound
+		},
+	}, WithMetricsRegistry(reg), WithAuthenticator(auth))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/flightPaths/SFO/LAX?leave=12/15/2020", nil)
+	server.ServeHTTP(w, r)
+	if got := counterValue(t, server.metrics.httpRequestsTotal, "/api/flightPaths/{from}/{to}", http.MethodGet, "500"); got != 1 {
+		t.Errorf("unexpected http_requests_total for flight search 500, got: %v want: 1", got)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/api/hotel/Four%20star/", nil)
+	server.ServeHTTP(w, r)
+	if got := counterValue(t, server.metrics.httpRequestsTotal, "/api/hotel/{description}/", http.MethodGet, "200"); got != 1 {
+		t.Errorf("unexpected http_requests_total for hotel search 200, got: %v want: 1", got)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/api/user/login", bytes.NewBufferString(`{"user":"test_user","password":"test_passw"}`))
+	server.ServeHTTP(w, r)
+	if got := counterValue(t, server.metrics.httpRequestsTotal, "/api/user/login", http.MethodPost, "401"); got != 1 {
+		t.Errorf("unexpected http_requests_total for login 401, got: %v want: 1", got)
+	}
+
+	if got := counterValue(t, server.metrics.repoOperationErrors, "GetFlightPaths", "other"); got != 1 {
+		t.Errorf("unexpected repo_operation_errors_total{op=GetFlightPaths,kind=other}, got: %v want: 1", got)
+	}
+	if got := counterValue(t, server.metrics.repoOperationErrors, "VerifyUserPassword", "not_found"); got != 1 {
+		t.Errorf("unexpected repo_operation_errors_total{op=VerifyUserPassword,kind=not_found}, got: %v want: 1", got)
+	}
+	if got := histogramSampleCount(t, server.metrics.repoOperationDuration, "GetHotels"); got != 1 {
+		t.Errorf("unexpected repo_operation_duration_seconds{op=GetHotels} sample count, got: %d want: 1", got)
+	}
+}
+
+func TestMetricsRegistryIsolatesServers(t *testing.T) {
+	t.Parallel()
+
+	// Two Servers with no explicit registry must not collide on
+	// duplicate collector registration.
+	s1 := New(&mockRepo{})
+	s2 := New(&mockRepo{})
+
+	if s1.MetricsRegistry == s2.MetricsRegistry {
+		t.Error("expected distinct Servers to get distinct default metrics registries")
+	}
+}
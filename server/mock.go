@@ -1,51 +1,134 @@
 package server
 
+import "context"
+
 type mockRepository interface {
-	GetAirports(string) (jsonAirportSearchResp, error)
-	GetFlightPaths(string, string, int) (jsonFlightSearchResp, error)
-	GetHotels(string, string) (jsonHotelSearchResp, error)
+	GetAirports(context.Context, string) (jsonAirportSearchResp, error)
+	GetFlightPaths(context.Context, string, string, int) (jsonFlightSearchResp, error)
+	GetHotels(context.Context, string, string) (jsonHotelSearchResp, error)
+
+	CreateUser(context.Context, string, string) error
+	VerifyUserPassword(context.Context, string, string) (bool, error)
+	GetUserFlights(context.Context, string) (jsonUserFlightsResp, error)
+	UpdateUserFlights(context.Context, string, []jsonBookedFlight) (jsonUserBookFlightResp, error)
+	GetOrCreateFederatedUser(context.Context, string, string, FederatedProfile) (string, error)
 
-	CreateUser(string, string) error
-	GetUserPassword(string) (string, error)
-	GetUserFlights(string) (jsonUserFlightsResp, error)
-	UpdateUserFlights(string, []jsonBookedFlight) (jsonUserBookFlightResp, error)
+	SearchFlightsV2(context.Context, flightSearchV2Params) (jsonFlightSearchV2Resp, error)
+	SearchHotelsV2(context.Context, hotelSearchV2Params) (jsonHotelSearchV2Resp, error)
 }
 
 type mockRepo struct {
-	GetAirportsFn    func(string) (jsonAirportSearchResp, error)
-	GetFlightPathsFn func(string, string, int) (jsonFlightSearchResp, error)
-	GetHotelsFn      func(string, string) (jsonHotelSearchResp, error)
+	GetAirportsFn    func(context.Context, string) (jsonAirportSearchResp, error)
+	GetFlightPathsFn func(context.Context, string, string, int) (jsonFlightSearchResp, error)
+	GetHotelsFn      func(context.Context, string, string) (jsonHotelSearchResp, error)
+
+	CreateUserFn               func(context.Context, string, string) error
+	VerifyUserPasswordFn       func(context.Context, string, string) (bool, error)
+	GetUserFlightsFn           func(context.Context, string) (jsonUserFlightsResp, error)
+	UpdateUserFlightsFn        func(context.Context, string, []jsonBookedFlight) (jsonUserBookFlightResp, error)
+	GetOrCreateFederatedUserFn func(context.Context, string, string, FederatedProfile) (string, error)
+
+	SearchFlightsV2Fn func(context.Context, flightSearchV2Params) (jsonFlightSearchV2Resp, error)
+	SearchHotelsV2Fn  func(context.Context, hotelSearchV2Params) (jsonHotelSearchV2Resp, error)
+}
+
+func (mr *mockRepo) GetAirports(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
+	return mr.GetAirportsFn(ctx, searchKey)
+}
+
+func (mr *mockRepo) GetFlightPaths(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+	return mr.GetFlightPathsFn(ctx, from, to, dayOfWeek)
+}
+
+func (mr *mockRepo) GetHotels(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
+	return mr.GetHotelsFn(ctx, description, location)
+}
+
+func (mr *mockRepo) VerifyUserPassword(ctx context.Context, username, password string) (bool, error) {
+	return mr.VerifyUserPasswordFn(ctx, username, password)
+}
+
+func (mr *mockRepo) GetUserFlights(ctx context.Context, username string) (jsonUserFlightsResp, error) {
+	return mr.GetUserFlightsFn(ctx, username)
+}
+
+func (mr *mockRepo) CreateUser(ctx context.Context, username, password string) error {
+	return mr.CreateUserFn(ctx, username, password)
+}
+
+func (mr *mockRepo) UpdateUserFlights(ctx context.Context, username string, bookedFlights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+	return mr.UpdateUserFlightsFn(ctx, username, bookedFlights)
+}
+
+func (mr *mockRepo) GetOrCreateFederatedUser(ctx context.Context, provider, subject string, profile FederatedProfile) (string, error) {
+	return mr.GetOrCreateFederatedUserFn(ctx, provider, subject, profile)
+}
+
+func (mr *mockRepo) SearchFlightsV2(ctx context.Context, p flightSearchV2Params) (jsonFlightSearchV2Resp, error) {
+	return mr.SearchFlightsV2Fn(ctx, p)
+}
+
+func (mr *mockRepo) SearchHotelsV2(ctx context.Context, p hotelSearchV2Params) (jsonHotelSearchV2Resp, error) {
+	return mr.SearchHotelsV2Fn(ctx, p)
+}
+
+type mockBookingRepo struct {
+	CreateBookingFn       func(ctx context.Context, username string, flights []jsonBookedFlight) (jsonBooking, error)
+	GetBookingFn          func(ctx context.Context, id string) (jsonBooking, error)
+	UpdateBookingStatusFn func(ctx context.Context, id string, status BookingStatus) (jsonBooking, error)
+}
+
+func (mr *mockBookingRepo) CreateBooking(ctx context.Context, username string, flights []jsonBookedFlight) (jsonBooking, error) {
+	return mr.CreateBookingFn(ctx, username, flights)
+}
+
+func (mr *mockBookingRepo) GetBooking(ctx context.Context, id string) (jsonBooking, error) {
+	return mr.GetBookingFn(ctx, id)
+}
+
+func (mr *mockBookingRepo) UpdateBookingStatus(ctx context.Context, id string, status BookingStatus) (jsonBooking, error) {
+	return mr.UpdateBookingStatusFn(ctx, id, status)
+}
+
+type mockRegistrationTokenRepo struct {
+	CreateRegistrationTokenFn   func(ctx context.Context, token jsonRegistrationToken) (jsonRegistrationToken, error)
+	GetRegistrationTokenFn      func(ctx context.Context, id string) (jsonRegistrationToken, error)
+	ListRegistrationTokensFn    func(ctx context.Context, from string, limit int) ([]jsonRegistrationToken, error)
+	UpdateRegistrationTokenFn   func(ctx context.Context, id string, token jsonRegistrationToken) (jsonRegistrationToken, error)
+	DeleteRegistrationTokenFn   func(ctx context.Context, id string) error
+	ReserveRegistrationTokenFn  func(ctx context.Context, token string) (string, error)
+	CompleteRegistrationTokenFn func(ctx context.Context, id string) error
+	ReleaseRegistrationTokenFn  func(ctx context.Context, id string) error
+}
 
-	CreateUserFn        func(string, string) error
-	GetUserPasswordFn   func(string) (string, error)
-	GetUserFlightsFn    func(string) (jsonUserFlightsResp, error)
-	UpdateUserFlightsFn func(string, []jsonBookedFlight) (jsonUserBookFlightResp, error)
+func (mr *mockRegistrationTokenRepo) CreateRegistrationToken(ctx context.Context, token jsonRegistrationToken) (jsonRegistrationToken, error) {
+	return mr.CreateRegistrationTokenFn(ctx, token)
 }
 
-func (mr *mockRepo) GetAirports(searchKey string) (jsonAirportSearchResp, error) {
-	return mr.GetAirportsFn(searchKey)
+func (mr *mockRegistrationTokenRepo) GetRegistrationToken(ctx context.Context, id string) (jsonRegistrationToken, error) {
+	return mr.GetRegistrationTokenFn(ctx, id)
 }
 
-func (mr *mockRepo) GetFlightPaths(from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
-	return mr.GetFlightPathsFn(from, to, dayOfWeek)
+func (mr *mockRegistrationTokenRepo) ListRegistrationTokens(ctx context.Context, from string, limit int) ([]jsonRegistrationToken, error) {
+	return mr.ListRegistrationTokensFn(ctx, from, limit)
 }
 
-func (mr *mockRepo) GetHotels(description, location string) (jsonHotelSearchResp, error) {
-	return mr.GetHotelsFn(description, location)
+func (mr *mockRegistrationTokenRepo) UpdateRegistrationToken(ctx context.Context, id string, token jsonRegistrationToken) (jsonRegistrationToken, error) {
+	return mr.UpdateRegistrationTokenFn(ctx, id, token)
 }
 
-func (mr *mockRepo) GetUserPassword(username string) (string, error) {
-	return mr.GetUserPasswordFn(username)
+func (mr *mockRegistrationTokenRepo) DeleteRegistrationToken(ctx context.Context, id string) error {
+	return mr.DeleteRegistrationTokenFn(ctx, id)
 }
 
-func (mr *mockRepo) GetUserFlights(username string) (jsonUserFlightsResp, error) {
-	return mr.GetUserFlightsFn(username)
+func (mr *mockRegistrationTokenRepo) ReserveRegistrationToken(ctx context.Context, token string) (string, error) {
+	return mr.ReserveRegistrationTokenFn(ctx, token)
 }
 
-func (mr *mockRepo) CreateUser(username, password string) error {
-	return mr.CreateUserFn(username, password)
+func (mr *mockRegistrationTokenRepo) CompleteRegistrationToken(ctx context.Context, id string) error {
+	return mr.CompleteRegistrationTokenFn(ctx, id)
 }
 
-func (mr *mockRepo) UpdateUserFlights(username string, bookedFlights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
-	return mr.UpdateUserFlightsFn(username, bookedFlights)
+func (mr *mockRegistrationTokenRepo) ReleaseRegistrationToken(ctx context.Context, id string) error {
+	return mr.ReleaseRegistrationTokenFn(ctx, id)
 }
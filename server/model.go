@@ -43,6 +43,11 @@ type jsonUser struct {
 	Name     string   `json:"name"`
 	Password string   `json:"password"`
 	Flights  []string `json:"flights"`
+
+	// Provider and Email are set for users created via
+	// GetOrCreateFederatedUser; both are empty for password accounts.
+	Provider string `json:"provider,omitempty"`
+	Email    string `json:"email,omitempty"`
 }
 
 type authedUser struct {
@@ -54,3 +59,34 @@ type jsonContext []string
 func (c *jsonContext) Add(msg string) {
 	*c = append(*c, msg)
 }
+
+// BookingStatus is the lifecycle state of a v2 booking.
+type BookingStatus string
+
+const (
+	BookingWaitingConfirmation BookingStatus = "WAITING_CONFIRMATION"
+	BookingConfirmed           BookingStatus = "CONFIRMED"
+	BookingCancelled           BookingStatus = "CANCELLED"
+	BookingCompleted           BookingStatus = "COMPLETED"
+)
+
+// jsonBooking is the document stored in the userData.bookings collection
+// and the v2 API's representation of it.
+type jsonBooking struct {
+	ID       string             `json:"id"`
+	User     string             `json:"user"`
+	Status   BookingStatus      `json:"status"`
+	Flights  []jsonBookedFlight `json:"flights"`
+	BookedOn string             `json:"bookedon"`
+}
+
+// jsonErrorResp is the v2 API's machine-readable error envelope, used in
+// place of the v1 API's bare {"failure": "..."} object.
+type jsonErrorResp struct {
+	Error jsonErrorDetail `json:"error"`
+}
+
+type jsonErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,29 @@
+package server
+
+import "encoding/base64"
+
+// encodePageToken opaquely wraps the position a v2 search resumes from, so
+// a client can walk a large result set (e.g. every flight out of JFK on a
+// given day) page by page: SearchFlightsV2 stores the sort key of the last
+// row it scanned, and SearchHotelsV2 stores the row offset of the next
+// page, since FTS results page by skip count.
+func encodePageToken(sortKey string) string {
+	if sortKey == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(sortKey))
+}
+
+// decodePageToken reverses encodePageToken. An empty or malformed token is
+// treated as "start from the beginning" rather than an error, since a
+// client omits page_token on its first request.
+func decodePageToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	sortKey, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ""
+	}
+	return string(sortKey)
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// PasswordHasher derives and verifies password credentials so that
+// plaintext passwords never need to be persisted or compared directly.
+type PasswordHasher interface {
+	// Hash derives a credential string safe to store alongside a user
+	// document.
+	Hash(password string) (string, error)
+
+	// Verify reports whether password matches stored. legacy reports
+	// whether stored was a pre-hashing plaintext credential, so the
+	// caller can migrate it to the current scheme.
+	Verify(password, stored string) (ok bool, legacy bool, err error)
+}
+
+const (
+	scryptN       = 32768
+	scryptR       = 8
+	scryptP       = 1
+	scryptKeyLen  = 64
+	scryptSaltLen = 16
+)
+
+// scryptHasher implements PasswordHasher using scrypt, storing credentials
+// as "scrypt:N:r:p$<hex salt>$<hex hash>" so future algorithms or
+// parameters can be introduced without a schema migration.
+type scryptHasher struct{}
+
+// NewScryptHasher returns the default PasswordHasher.
+func NewScryptHasher() PasswordHasher {
+	return scryptHasher{}
+}
+
+func (scryptHasher) Hash(password string) (string, error) {
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("scrypt:%d:%d:%d$%s$%s", scryptN, scryptR, scryptP, hex.EncodeToString(salt), hex.EncodeToString(key)), nil
+}
+
+func (scryptHasher) Verify(password, stored string) (bool, bool, error) {
+	if stored == "" {
+		// Federated accounts (see GetOrCreateFederatedUser) are created
+		// with no password credential at all, so stored is "" for them.
+		// Without this check, a blank stored credential would fall into
+		// the legacy plaintext branch below and ConstantTimeCompare would
+		// happily match an empty submitted password, letting anyone log
+		// in as any OAuth-created account.
+		return false, false, nil
+	}
+
+	if !strings.HasPrefix(stored, "scrypt:") {
+		// Legacy plaintext credential from before hashing was introduced.
+		match := subtle.ConstantTimeCompare([]byte(password), []byte(stored)) == 1
+		return match, true, nil
+	}
+
+	fields := strings.Split(stored, "$")
+	if len(fields) != 3 {
+		return false, false, errors.New("malformed scrypt credential")
+	}
+
+	params := strings.Split(strings.TrimPrefix(fields[0], "scrypt:"), ":")
+	if len(params) != 3 {
+		return false, false, errors.New("malformed scrypt credential")
+	}
+
+	n, err := strconv.Atoi(params[0])
+	if err != nil {
+		return false, false, fmt.Errorf("malformed scrypt cost parameter: %w", err)
+	}
+	r, err := strconv.Atoi(params[1])
+	if err != nil {
+		return false, false, fmt.Errorf("malformed scrypt block size parameter: %w", err)
+	}
+	p, err := strconv.Atoi(params[2])
+	if err != nil {
+		return false, false, fmt.Errorf("malformed scrypt parallelization parameter: %w", err)
+	}
+
+	salt, err := hex.DecodeString(fields[1])
+	if err != nil {
+		return false, false, fmt.Errorf("malformed scrypt salt: %w", err)
+	}
+	wantKey, err := hex.DecodeString(fields[2])
+	if err != nil {
+		return false, false, fmt.Errorf("malformed scrypt hash: %w", err)
+	}
+
+	gotKey, err := scrypt.Key([]byte(password), salt, n, r, p, len(wantKey))
+	if err != nil {
+		return false, false, err
+	}
+
+	return subtle.ConstantTimeCompare(gotKey, wantKey) == 1, false, nil
+}
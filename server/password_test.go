@@ -0,0 +1,78 @@
+package server
+
+import "testing"
+
+func TestScryptHasherHashAndVerify(t *testing.T) {
+	t.Parallel()
+
+	h := NewScryptHasher()
+
+	hash, err := h.Hash("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("error hashing password: %v", err)
+	}
+
+	ok, legacy, err := h.Verify("correct horse battery staple", hash)
+	if err != nil {
+		t.Fatalf("error verifying password: %v", err)
+	}
+	if !ok {
+		t.Error("expected matching password to verify")
+	}
+	if legacy {
+		t.Error("expected a freshly hashed credential to not be reported as legacy")
+	}
+
+	ok, _, err = h.Verify("wrong password", hash)
+	if err != nil {
+		t.Fatalf("error verifying password: %v", err)
+	}
+	if ok {
+		t.Error("expected mismatched password to not verify")
+	}
+}
+
+func TestScryptHasherVerifyLegacyPlaintext(t *testing.T) {
+	t.Parallel()
+
+	h := NewScryptHasher()
+
+	ok, legacy, err := h.Verify("test_passw", "test_passw")
+	if err != nil {
+		t.Fatalf("error verifying password: %v", err)
+	}
+	if !ok {
+		t.Error("expected matching legacy password to verify")
+	}
+	if !legacy {
+		t.Error("expected a plaintext credential to be reported as legacy")
+	}
+
+	ok, _, err = h.Verify("wrong password", "test_passw")
+	if err != nil {
+		t.Fatalf("error verifying password: %v", err)
+	}
+	if ok {
+		t.Error("expected mismatched legacy password to not verify")
+	}
+}
+
+// TestScryptHasherVerifyRejectsEmptyStoredCredential guards against
+// federated accounts (stored == "", see GetOrCreateFederatedUser) being
+// takeable over by posting an empty password to /api/user/login.
+func TestScryptHasherVerifyRejectsEmptyStoredCredential(t *testing.T) {
+	t.Parallel()
+
+	h := NewScryptHasher()
+
+	ok, legacy, err := h.Verify("", "")
+	if err != nil {
+		t.Fatalf("error verifying password: %v", err)
+	}
+	if ok {
+		t.Error("expected an empty submitted password to never verify against an empty stored credential")
+	}
+	if legacy {
+		t.Error("expected an empty stored credential to not be reported as legacy")
+	}
+}
@@ -0,0 +1,305 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+// registrationTokenPattern restricts a registration token's characters so
+// it's safe to use directly as a Couchbase document key.
+var registrationTokenPattern = regexp.MustCompile(`^[[:ascii:][:digit:]_]*$`)
+
+var (
+	// ErrRegistrationTokenNotFound is returned by the admin CRUD endpoints
+	// when a registration token ID doesn't resolve to a stored document.
+	ErrRegistrationTokenNotFound = errors.New("registration token does not exist")
+
+	// ErrRegistrationTokenMalformed is returned when a registration
+	// token's characters don't match registrationTokenPattern.
+	ErrRegistrationTokenMalformed = errors.New("registration token contains invalid characters")
+
+	// ErrRegistrationTokenInvalid is returned by ReserveRegistrationToken
+	// when the token is missing, expired or exhausted. The three cases are
+	// folded together, the same way service.ErrBadAuth folds "no such
+	// user" into "bad password", so a failed signup can't be used to probe
+	// which tokens exist or how many uses they have left.
+	ErrRegistrationTokenInvalid = errors.New("registration token is missing, expired or exhausted")
+)
+
+// jsonRegistrationToken is the document stored in the
+// userData.registrationTokens collection, keyed by its own Token value,
+// and the admin API's representation of it.
+type jsonRegistrationToken struct {
+	ID string `json:"id"`
+
+	// Token is the value a signup request must present. It doubles as the
+	// document's key, so it must match registrationTokenPattern.
+	Token string `json:"token"`
+
+	// UsesAllowed caps how many signups the token can complete. Nil means
+	// unlimited.
+	UsesAllowed *int `json:"uses_allowed"`
+
+	// Pending counts signups that have reserved the token but not yet
+	// completed or failed.
+	Pending int `json:"pending"`
+
+	// Completed counts signups that have successfully used the token.
+	Completed int `json:"completed"`
+
+	// ExpiryTimeMs is the Unix time in milliseconds after which the token
+	// can no longer be reserved. Nil means it never expires.
+	ExpiryTimeMs *int64 `json:"expiry_time"`
+}
+
+// RegistrationTokenRepository backs the admin registration-token CRUD
+// endpoints and the signup-time reservation gate. It is kept separate
+// from Repository for the same reason BookingRepository is: its
+// CAS-guarded counters are a distinct concern from the read-mostly
+// flight/hotel/user lookups Repository exposes.
+type RegistrationTokenRepository interface {
+	CreateRegistrationToken(ctx context.Context, token jsonRegistrationToken) (jsonRegistrationToken, error)
+	GetRegistrationToken(ctx context.Context, id string) (jsonRegistrationToken, error)
+	ListRegistrationTokens(ctx context.Context, from string, limit int) ([]jsonRegistrationToken, error)
+	UpdateRegistrationToken(ctx context.Context, id string, token jsonRegistrationToken) (jsonRegistrationToken, error)
+	DeleteRegistrationToken(ctx context.Context, id string) error
+
+	// ReserveRegistrationToken atomically increments token's pending count
+	// and returns its ID, or ErrRegistrationTokenInvalid if it can't be
+	// reserved.
+	ReserveRegistrationToken(ctx context.Context, token string) (string, error)
+
+	// CompleteRegistrationToken moves a reservation from pending to
+	// completed after a successful signup.
+	CompleteRegistrationToken(ctx context.Context, id string) error
+
+	// ReleaseRegistrationToken undoes a reservation after a failed signup,
+	// so the token isn't charged for an account that was never created.
+	ReleaseRegistrationToken(ctx context.Context, id string) error
+}
+
+// maxRegistrationTokenCASRetries bounds how many times a counter update
+// retries after losing a CAS race to a concurrent signup before giving up.
+const maxRegistrationTokenCASRetries = 5
+
+func (cr *CBRepository) registrationTokensCollection() *gocb.Collection {
+	return cr.userBucket.Scope("userData").Collection("registrationTokens")
+}
+
+func (cr *CBRepository) CreateRegistrationToken(ctx context.Context, rt jsonRegistrationToken) (jsonRegistrationToken, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	if !registrationTokenPattern.MatchString(rt.Token) {
+		return jsonRegistrationToken{}, ErrRegistrationTokenMalformed
+	}
+
+	rt.ID = rt.Token
+	rt.Pending = 0
+	rt.Completed = 0
+
+	if _, err := cr.registrationTokensCollection().Insert(rt.ID, rt, &gocb.InsertOptions{Timeout: timeoutFromContext(ctx)}); err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	return rt, nil
+}
+
+func (cr *CBRepository) GetRegistrationToken(ctx context.Context, id string) (jsonRegistrationToken, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	res, err := cr.registrationTokensCollection().Get(id, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
+	if errors.Is(err, gocb.ErrDocumentNotFound) {
+		return jsonRegistrationToken{}, ErrRegistrationTokenNotFound
+	} else if err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	var rt jsonRegistrationToken
+	if err := res.Content(&rt); err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	return rt, nil
+}
+
+// ListRegistrationTokens returns up to limit tokens with an ID greater
+// than from, ordered by ID, so the admin UI can page through a large
+// token set without an ever-growing OFFSET.
+func (cr *CBRepository) ListRegistrationTokens(ctx context.Context, from string, limit int) ([]jsonRegistrationToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	defer observeCouchbaseDuration("query", time.Now())
+
+	queryStr := "SELECT r.* FROM `" + cr.userBucket.Name() + "`.`userData`.`registrationTokens` AS r" +
+		" WHERE META(r).id > $from ORDER BY META(r).id ASC LIMIT $limit"
+
+	logCouchbaseQuery(ctx, "query", queryStr)
+	rows, err := cr.cluster.Query(queryStr, &gocb.QueryOptions{
+		NamedParameters: map[string]interface{}{"from": from, "limit": limit},
+		Timeout:         timeoutFromContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []jsonRegistrationToken{}
+	var rt jsonRegistrationToken
+	for rows.Next() {
+		if err := rows.Row(&rt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, rt)
+		rt = jsonRegistrationToken{}
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (cr *CBRepository) UpdateRegistrationToken(ctx context.Context, id string, rt jsonRegistrationToken) (jsonRegistrationToken, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	rt.ID = id
+	if _, err := cr.registrationTokensCollection().Replace(id, rt, &gocb.ReplaceOptions{Timeout: timeoutFromContext(ctx)}); errors.Is(err, gocb.ErrDocumentNotFound) {
+		return jsonRegistrationToken{}, ErrRegistrationTokenNotFound
+	} else if err != nil {
+		return jsonRegistrationToken{}, err
+	}
+
+	return rt, nil
+}
+
+func (cr *CBRepository) DeleteRegistrationToken(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	if _, err := cr.registrationTokensCollection().Remove(id, &gocb.RemoveOptions{Timeout: timeoutFromContext(ctx)}); errors.Is(err, gocb.ErrDocumentNotFound) {
+		return ErrRegistrationTokenNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cr *CBRepository) ReserveRegistrationToken(ctx context.Context, token string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	for attempt := 0; attempt < maxRegistrationTokenCASRetries; attempt++ {
+		res, err := cr.registrationTokensCollection().Get(token, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
+		if errors.Is(err, gocb.ErrDocumentNotFound) {
+			return "", ErrRegistrationTokenInvalid
+		} else if err != nil {
+			return "", err
+		}
+
+		cas := res.Cas()
+		var rt jsonRegistrationToken
+		if err := res.Content(&rt); err != nil {
+			return "", err
+		}
+
+		if !registrationTokenUsable(rt) {
+			return "", ErrRegistrationTokenInvalid
+		}
+
+		rt.Pending++
+		_, err = cr.registrationTokensCollection().Replace(token, rt, &gocb.ReplaceOptions{Cas: cas, Timeout: timeoutFromContext(ctx)})
+		if errors.Is(err, gocb.ErrCasMismatch) {
+			// Another signup reserved the token first; retry against its
+			// current state rather than failing a request that would
+			// otherwise succeed.
+			continue
+		} else if err != nil {
+			return "", err
+		}
+
+		return rt.ID, nil
+	}
+
+	return "", errors.New("exceeded retry limit reserving registration token")
+}
+
+// registrationTokenUsable reports whether rt has neither expired nor used
+// up its allotted uses, not counting the reservation being attempted.
+func registrationTokenUsable(rt jsonRegistrationToken) bool {
+	if rt.ExpiryTimeMs != nil && time.Now().UnixMilli() > *rt.ExpiryTimeMs {
+		return false
+	}
+	if rt.UsesAllowed != nil && rt.Pending+rt.Completed >= *rt.UsesAllowed {
+		return false
+	}
+	return true
+}
+
+func (cr *CBRepository) CompleteRegistrationToken(ctx context.Context, id string) error {
+	return cr.adjustRegistrationTokenCounts(ctx, id, -1, 1)
+}
+
+func (cr *CBRepository) ReleaseRegistrationToken(ctx context.Context, id string) error {
+	return cr.adjustRegistrationTokenCounts(ctx, id, -1, 0)
+}
+
+// adjustRegistrationTokenCounts applies pendingDelta/completedDelta to
+// id's counters under CAS, retrying against a concurrent update rather
+// than losing one side of the race.
+func (cr *CBRepository) adjustRegistrationTokenCounts(ctx context.Context, id string, pendingDelta, completedDelta int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	for attempt := 0; attempt < maxRegistrationTokenCASRetries; attempt++ {
+		res, err := cr.registrationTokensCollection().Get(id, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
+		if errors.Is(err, gocb.ErrDocumentNotFound) {
+			return ErrRegistrationTokenNotFound
+		} else if err != nil {
+			return err
+		}
+
+		cas := res.Cas()
+		var rt jsonRegistrationToken
+		if err := res.Content(&rt); err != nil {
+			return err
+		}
+
+		rt.Pending += pendingDelta
+		rt.Completed += completedDelta
+
+		_, err = cr.registrationTokensCollection().Replace(id, rt, &gocb.ReplaceOptions{Cas: cas, Timeout: timeoutFromContext(ctx)})
+		if errors.Is(err, gocb.ErrCasMismatch) {
+			continue
+		}
+		return err
+	}
+
+	return errors.New("exceeded retry limit updating registration token")
+}
@@ -1,8 +1,11 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"math"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,52 +15,118 @@ import (
 )
 
 type Repository interface {
-	GetAirports(string) (jsonAirportSearchResp, error)
-	GetFlightPaths(string, string, int) (jsonFlightSearchResp, error)
-	GetHotels(string, string) (jsonHotelSearchResp, error)
-
-	CreateUser(string, string) error
-	GetUserPassword(string) (string, error)
-	GetUserFlights(string) (jsonUserFlightsResp, error)
-	UpdateUserFlights(string, []jsonBookedFlight) (jsonUserBookFlightResp, error)
+	GetAirports(context.Context, string) (jsonAirportSearchResp, error)
+	GetFlightPaths(context.Context, string, string, int) (jsonFlightSearchResp, error)
+	GetHotels(context.Context, string, string) (jsonHotelSearchResp, error)
+
+	CreateUser(context.Context, string, string) error
+	VerifyUserPassword(context.Context, string, string) (bool, error)
+	GetUserFlights(context.Context, string) (jsonUserFlightsResp, error)
+	UpdateUserFlights(context.Context, string, []jsonBookedFlight) (jsonUserBookFlightResp, error)
+	GetOrCreateFederatedUser(context.Context, string, string, FederatedProfile) (string, error)
+
+	SearchFlightsV2(context.Context, flightSearchV2Params) (jsonFlightSearchV2Resp, error)
+	SearchHotelsV2(context.Context, hotelSearchV2Params) (jsonHotelSearchV2Resp, error)
+}
+
+// defaultPageSizeV2 bounds how many rows a v2 search handler returns when
+// the caller doesn't specify count/page size.
+const defaultPageSizeV2 = 20
+
+// flightSearchV2Params is the parsed form of a GET /api/v2/flights query.
+type flightSearchV2Params struct {
+	From            string
+	To              string
+	Departure       time.Time
+	DepartureRadius time.Duration
+	PriceMax        float64
+	Count           int
+	PageToken       string
+}
+
+// hotelSearchV2Params is the parsed form of a GET /api/v2/hotels query.
+type hotelSearchV2Params struct {
+	Query     string
+	Location  string
+	Count     int
+	PageToken string
+}
+
+// jsonFlightSearchV2Resp is the v2 counterpart to jsonFlightSearchResp,
+// adding a cursor for callers that need more rows than fit in one page.
+type jsonFlightSearchV2Resp struct {
+	Data          []jsonFlight `json:"data"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+	Context       jsonContext  `json:"context"`
+}
+
+// jsonHotelSearchV2Resp is the v2 counterpart to jsonHotelSearchResp.
+type jsonHotelSearchV2Resp struct {
+	Data          []jsonHotel `json:"data"`
+	NextPageToken string      `json:"next_page_token,omitempty"`
+	Context       jsonContext `json:"context"`
 }
 
 type CBRepository struct {
-	cluster       *gocb.Cluster
-	defaultBucket *gocb.Bucket
-	userBucket    *gocb.Bucket
+	cluster        *gocb.Cluster
+	defaultBucket  *gocb.Bucket
+	userBucket     *gocb.Bucket
+	passwordHasher PasswordHasher
 }
 
+// NewCBRepository connects to Couchbase using RepositoryConfigFromEnv.
 func NewCBRepository() (*CBRepository, error) {
-	var (
-		cbConnStr    = "couchbase://localhost"
-		cbDataBucket = "travel-sample"
-		cbUserBucket = "travel-users"
-		cbUsername   = "Administrator"
-		cbPassword   = "password"
-	)
+	return NewCBRepositoryWithConfig(RepositoryConfigFromEnv())
+}
 
+// NewCBRepositoryWithConfig connects to Couchbase using an explicit
+// config, e.g. for tests that don't want to depend on the environment.
+func NewCBRepositoryWithConfig(cfg RepositoryConfig) (*CBRepository, error) {
 	clusterOpts := gocb.ClusterOptions{
 		Authenticator: gocb.PasswordAuthenticator{
-			Username: cbUsername,
-			Password: cbPassword,
+			Username: cfg.Username,
+			Password: cfg.Password,
 		},
 	}
 
-	cluster, err := gocb.Connect(cbConnStr, clusterOpts)
+	cluster, err := gocb.Connect(cfg.ConnString, clusterOpts)
 	if err != nil {
 		return nil, err
 	}
 
 	return &CBRepository{
-		cluster:       cluster,
-		defaultBucket: cluster.Bucket(cbDataBucket),
-		userBucket:    cluster.Bucket(cbUserBucket),
+		cluster:        cluster,
+		defaultBucket:  cluster.Bucket(cfg.DataBucket),
+		userBucket:     cluster.Bucket(cfg.UserBucket),
+		passwordHasher: NewScryptHasher(),
 	}, nil
 }
 
+// Cluster exposes the underlying gocb Cluster, e.g. so callers can build
+// a health.CouchbaseChecker against the same connection.
+func (cr *CBRepository) Cluster() *gocb.Cluster {
+	return cr.cluster
+}
+
+// timeoutFromContext derives a per-operation timeout from ctx's deadline.
+// gocb v2.1.8 predates context.Context support in its Options structs, so
+// this is how a caller's deadline gets enforced on the underlying N1QL/FTS/KV
+// call until the client can be upgraded to accept ctx directly.
+func timeoutFromContext(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return 0
+}
+
 // GetAirports returns all airports matching the search key provided
-func (cr *CBRepository) GetAirports(searchKey string) (jsonAirportSearchResp, error) {
+func (cr *CBRepository) GetAirports(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonAirportSearchResp{}, err
+	}
+
+	defer observeCouchbaseDuration("query", time.Now())
+
 	var queryStr string
 	queryParams := make([]interface{}, 1)
 
@@ -78,7 +147,11 @@ func (cr *CBRepository) GetAirports(searchKey string) (jsonAirportSearchResp, er
 
 	var respData jsonAirportSearchResp
 	respData.Context.Add(queryStr)
-	rows, err := cr.cluster.Query(queryStr, &gocb.QueryOptions{PositionalParameters: queryParams})
+	logCouchbaseQuery(ctx, "query", queryStr)
+	rows, err := cr.cluster.Query(queryStr, &gocb.QueryOptions{
+		PositionalParameters: queryParams,
+		Timeout:              timeoutFromContext(ctx),
+	})
 	if err != nil {
 		return jsonAirportSearchResp{}, err
 	}
@@ -100,7 +173,13 @@ func (cr *CBRepository) GetAirports(searchKey string) (jsonAirportSearchResp, er
 	return respData, nil
 }
 
-func (cr *CBRepository) GetFlightPaths(from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+func (cr *CBRepository) GetFlightPaths(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonFlightSearchResp{}, err
+	}
+
+	defer observeCouchbaseDuration("query", time.Now())
+
 	var respData jsonFlightSearchResp
 	queryParams := make(map[string]interface{}, 1)
 
@@ -115,8 +194,12 @@ func (cr *CBRepository) GetFlightPaths(from, to string, dayOfWeek int) (jsonFlig
 			" WHERE airportname=$toAirport;"
 
 	respData.Context.Add(queryStr)
+	logCouchbaseQuery(ctx, "query", queryStr)
 	var airportInfo jsonAirportInfo
-	rows, err := cr.cluster.Query(queryStr, &gocb.QueryOptions{NamedParameters: queryParams})
+	rows, err := cr.cluster.Query(queryStr, &gocb.QueryOptions{
+		NamedParameters: queryParams,
+		Timeout:         timeoutFromContext(ctx),
+	})
 	if err != nil {
 		return jsonFlightSearchResp{}, err
 	}
@@ -130,6 +213,10 @@ func (cr *CBRepository) GetFlightPaths(from, to string, dayOfWeek int) (jsonFlig
 		return jsonFlightSearchResp{}, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return jsonFlightSearchResp{}, err
+	}
+
 	// Search for flights
 	queryParams["fromFaa"] = airportInfo.FromFaa
 	queryParams["toFaa"] = airportInfo.ToFaa
@@ -145,7 +232,11 @@ func (cr *CBRepository) GetFlightPaths(from, to string, dayOfWeek int) (jsonFlig
 			" ORDER BY a.name ASC;"
 
 	respData.Context.Add(queryStr)
-	rows, err = cr.cluster.Query(queryStr, &gocb.QueryOptions{NamedParameters: queryParams})
+	logCouchbaseQuery(ctx, "query", queryStr)
+	rows, err = cr.cluster.Query(queryStr, &gocb.QueryOptions{
+		NamedParameters: queryParams,
+		Timeout:         timeoutFromContext(ctx),
+	})
 	if err != nil {
 		return jsonFlightSearchResp{}, err
 	}
@@ -169,7 +260,13 @@ func (cr *CBRepository) GetFlightPaths(from, to string, dayOfWeek int) (jsonFlig
 	return respData, nil
 }
 
-func (cr *CBRepository) GetHotels(description, location string) (jsonHotelSearchResp, error) {
+func (cr *CBRepository) GetHotels(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonHotelSearchResp{}, err
+	}
+
+	defer observeCouchbaseDuration("search", time.Now())
+
 	var respData jsonHotelSearchResp
 	var defaultCollection = cr.defaultBucket.DefaultCollection()
 
@@ -191,13 +288,21 @@ func (cr *CBRepository) GetHotels(description, location string) (jsonHotelSearch
 		))
 	}
 
-	results, err := cr.cluster.SearchQuery("hotels", qp, &gocb.SearchOptions{Limit: 100})
+	logCouchbaseQuery(ctx, "search", "hotels")
+	results, err := cr.cluster.SearchQuery("hotels", qp, &gocb.SearchOptions{
+		Limit:   100,
+		Timeout: timeoutFromContext(ctx),
+	})
 	if err != nil {
 		return jsonHotelSearchResp{}, err
 	}
 
 	respData.Data = []jsonHotel{}
 	for results.Next() {
+		if err := ctx.Err(); err != nil {
+			return jsonHotelSearchResp{}, err
+		}
+
 		res, _ := defaultCollection.LookupIn(results.Row().ID, []gocb.LookupInSpec{
 			gocb.GetSpec("country", nil),
 			gocb.GetSpec("city", nil),
@@ -205,7 +310,7 @@ func (cr *CBRepository) GetHotels(description, location string) (jsonHotelSearch
 			gocb.GetSpec("address", nil),
 			gocb.GetSpec("name", nil),
 			gocb.GetSpec("description", nil),
-		}, nil)
+		}, &gocb.LookupInOptions{Timeout: timeoutFromContext(ctx)})
 		// We ignore errors here since some hotels are missing various
 		//  pieces of data, but every key exists since it came from FTS.
 
@@ -223,26 +328,59 @@ func (cr *CBRepository) GetHotels(description, location string) (jsonHotelSearch
 	return respData, nil
 }
 
-func (cr *CBRepository) GetUserPassword(username string) (string, error) {
+// VerifyUserPassword reports whether password is correct for username. If
+// the stored credential predates password hashing, a successful verify
+// transparently migrates it to the current scheme.
+func (cr *CBRepository) VerifyUserPassword(ctx context.Context, username, password string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
 	userDataScope := cr.userBucket.Scope("userData")
 	userCollection := userDataScope.Collection("users")
 
 	res, err := userCollection.LookupIn(username, []gocb.LookupInSpec{
 		gocb.GetSpec("password", nil),
-	}, nil)
+	}, &gocb.LookupInOptions{Timeout: timeoutFromContext(ctx)})
 	if err != nil {
-		return "", err
+		return false, err
 	}
 
-	var password string
-	if err = res.ContentAt(0, &password); err != nil {
-		return "", err
+	var stored string
+	if err = res.ContentAt(0, &stored); err != nil {
+		return false, err
 	}
 
-	return password, nil
+	ok, legacy, err := cr.passwordHasher.Verify(password, stored)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+
+	if legacy {
+		// Best-effort migration: if this fails the user still
+		// authenticated fine and will be migrated on a later login.
+		if hash, err := cr.passwordHasher.Hash(password); err == nil {
+			userCollection.MutateIn(username, []gocb.MutateInSpec{
+				gocb.UpsertSpec("password", hash, nil),
+			}, &gocb.MutateInOptions{Timeout: timeoutFromContext(ctx)})
+		}
+	}
+
+	return true, nil
 }
 
-func (cr *CBRepository) GetUserFlights(username string) (jsonUserFlightsResp, error) {
+func (cr *CBRepository) GetUserFlights(ctx context.Context, username string) (jsonUserFlightsResp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonUserFlightsResp{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
 	var respData jsonUserFlightsResp
 
 	userDataScope := cr.userBucket.Scope("userData")
@@ -252,7 +390,7 @@ func (cr *CBRepository) GetUserFlights(username string) (jsonUserFlightsResp, er
 	var flightIDs []string
 	res, err := userCollection.LookupIn(username, []gocb.LookupInSpec{
 		gocb.GetSpec("flights", nil),
-	}, nil)
+	}, &gocb.LookupInOptions{Timeout: timeoutFromContext(ctx)})
 	if err != nil {
 		return jsonUserFlightsResp{}, err
 	}
@@ -262,7 +400,11 @@ func (cr *CBRepository) GetUserFlights(username string) (jsonUserFlightsResp, er
 	var flight jsonBookedFlight
 	respData.Data = []jsonBookedFlight{}
 	for _, flightID := range flightIDs {
-		res, err := flightCollection.Get(flightID, nil)
+		if err := ctx.Err(); err != nil {
+			return jsonUserFlightsResp{}, err
+		}
+
+		res, err := flightCollection.Get(flightID, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
 		if err != nil {
 			return jsonUserFlightsResp{}, err
 		}
@@ -274,23 +416,40 @@ func (cr *CBRepository) GetUserFlights(username string) (jsonUserFlightsResp, er
 	return respData, nil
 }
 
-func (cr *CBRepository) CreateUser(username, password string) error {
+func (cr *CBRepository) CreateUser(ctx context.Context, username, password string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
 	userDataScope := cr.userBucket.Scope("userData")
 	userCollection := userDataScope.Collection("users")
 
+	hash, err := cr.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
 	user := jsonUser{
 		Name:     username,
-		Password: password,
+		Password: hash,
 		Flights:  nil,
 	}
-	if _, err := userCollection.Insert(username, user, nil); err != nil {
+	if _, err := userCollection.Insert(username, user, &gocb.InsertOptions{Timeout: timeoutFromContext(ctx)}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (cr *CBRepository) UpdateUserFlights(username string, bookedFlights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+func (cr *CBRepository) UpdateUserFlights(ctx context.Context, username string, bookedFlights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonUserBookFlightResp{}, err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
 	var respData jsonUserBookFlightResp
 
 	userDataScope := cr.userBucket.Scope("userData")
@@ -298,7 +457,7 @@ func (cr *CBRepository) UpdateUserFlights(username string, bookedFlights []jsonB
 	flightCollection := userDataScope.Collection("flights")
 
 	var user jsonUser
-	res, err := userCollection.Get(username, nil)
+	res, err := userCollection.Get(username, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)})
 	if err != nil {
 		return jsonUserBookFlightResp{}, err
 	}
@@ -307,6 +466,10 @@ func (cr *CBRepository) UpdateUserFlights(username string, bookedFlights []jsonB
 	res.Content(&user)
 
 	for _, flight := range bookedFlights {
+		if err := ctx.Err(); err != nil {
+			return jsonUserBookFlightResp{}, err
+		}
+
 		flight.BookedOn = time.Now().Format("01/02/2006")
 		respData.Data.Added = append(respData.Data.Added, flight)
 
@@ -316,13 +479,13 @@ func (cr *CBRepository) UpdateUserFlights(username string, bookedFlights []jsonB
 		}
 
 		user.Flights = append(user.Flights, flightID.String())
-		_, err = flightCollection.Upsert(flightID.String(), flight, nil)
+		_, err = flightCollection.Upsert(flightID.String(), flight, &gocb.UpsertOptions{Timeout: timeoutFromContext(ctx)})
 		if err != nil {
 			return jsonUserBookFlightResp{}, err
 		}
 	}
 
-	opts := gocb.ReplaceOptions{Cas: cas}
+	opts := gocb.ReplaceOptions{Cas: cas, Timeout: timeoutFromContext(ctx)}
 	_, err = userCollection.Replace(username, user, &opts)
 	if err != nil {
 		// We intentionally do not handle CAS mismatch, as if the users
@@ -332,3 +495,255 @@ func (cr *CBRepository) UpdateUserFlights(username string, bookedFlights []jsonB
 
 	return respData, nil
 }
+
+// ErrFederatedAccountCollision is returned by GetOrCreateFederatedUser when
+// the provider:subject username is already taken by a document that wasn't
+// itself created via federation (it has a Password set). Adopting that
+// document as-is would let whoever created it via username/password signup
+// pre-hijack the federated identity the first time its real owner signs in,
+// so GetOrCreateFederatedUser refuses instead of returning the collided
+// username.
+var ErrFederatedAccountCollision = errors.New("username is already registered as a password account")
+
+// GetOrCreateFederatedUser returns the canonical username for a federated
+// identity (provider, subject), creating a password-less user document on
+// first sign-in. The username is deterministic (provider:subject) so a
+// later sign-in from the same identity resolves to the same account.
+func (cr *CBRepository) GetOrCreateFederatedUser(ctx context.Context, provider, subject string, profile FederatedProfile) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	defer observeCouchbaseDuration("kv", time.Now())
+
+	username := provider + ":" + subject
+
+	userDataScope := cr.userBucket.Scope("userData")
+	userCollection := userDataScope.Collection("users")
+
+	if res, err := userCollection.Get(username, &gocb.GetOptions{Timeout: timeoutFromContext(ctx)}); err == nil {
+		var existing jsonUser
+		if err := res.Content(&existing); err != nil {
+			return "", err
+		}
+		if existing.Password != "" {
+			return "", ErrFederatedAccountCollision
+		}
+		return username, nil
+	} else if !errors.Is(err, gocb.ErrDocumentNotFound) {
+		return "", err
+	}
+
+	user := jsonUser{
+		Name:     username,
+		Provider: provider,
+		Email:    profile.Email,
+	}
+	if _, err := userCollection.Insert(username, user, &gocb.InsertOptions{Timeout: timeoutFromContext(ctx)}); err != nil && !errors.Is(err, gocb.ErrDocumentExists) {
+		return "", err
+	}
+
+	return username, nil
+}
+
+// SearchFlightsV2 searches scheduled flights from the FAA code p.From to
+// p.To on p.Departure's day of week, optionally narrowing to a departure
+// time-of-day window and a maximum price. Results page via an opaque
+// cursor over the flight number sort key, rather than OFFSET/LIMIT, so a
+// day with many scheduled flights can be walked without re-scanning rows
+// already returned.
+func (cr *CBRepository) SearchFlightsV2(ctx context.Context, p flightSearchV2Params) (jsonFlightSearchV2Resp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonFlightSearchV2Resp{}, err
+	}
+
+	defer observeCouchbaseDuration("query", time.Now())
+
+	count := p.Count
+	if count <= 0 {
+		count = defaultPageSizeV2
+	}
+
+	queryParams := map[string]interface{}{
+		"fromFaa":     p.From,
+		"toFaa":       p.To,
+		"dayOfWeek":   int(p.Departure.Weekday()),
+		"afterFlight": decodePageToken(p.PageToken),
+		"count":       count,
+	}
+	queryStr :=
+		"SELECT a.name, s.flight, s.utc, r.sourceairport, r.destinationairport, r.equipment" +
+			" FROM `travel-sample` AS r" +
+			" UNNEST r.schedule AS s" +
+			" JOIN `travel-sample` AS a ON KEYS r.airlineid" +
+			" WHERE r.sourceairport=$fromFaa" +
+			" AND r.destinationairport=$toFaa" +
+			" AND s.day=$dayOfWeek" +
+			" AND s.flight > $afterFlight" +
+			" ORDER BY s.flight ASC" +
+			" LIMIT $count;"
+
+	var respData jsonFlightSearchV2Resp
+	respData.Context.Add(queryStr)
+	logCouchbaseQuery(ctx, "query", queryStr)
+
+	rows, err := cr.cluster.Query(queryStr, &gocb.QueryOptions{
+		NamedParameters: queryParams,
+		Timeout:         timeoutFromContext(ctx),
+	})
+	if err != nil {
+		return jsonFlightSearchV2Resp{}, err
+	}
+
+	respData.Data = []jsonFlight{}
+	var flight jsonFlight
+	var lastFlight string
+	scanned := 0
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return jsonFlightSearchV2Resp{}, err
+		}
+
+		if err = rows.Row(&flight); err != nil {
+			return jsonFlightSearchV2Resp{}, err
+		}
+
+		// The cursor advances past every scanned row, including ones the
+		// price/departure filters below drop, so a filtered page still
+		// leads on to the next one.
+		scanned++
+		lastFlight = flight.Flight
+
+		flight.FlightTime = int(math.Ceil(rand.Float64() * 8000))
+		flight.Price = math.Ceil(float64(flight.FlightTime)/8*100) / 100
+
+		if p.PriceMax > 0 && flight.Price > p.PriceMax {
+			flight = jsonFlight{}
+			continue
+		}
+		if p.DepartureRadius > 0 && !withinDepartureRadius(flight.Utc, p.Departure, p.DepartureRadius) {
+			flight = jsonFlight{}
+			continue
+		}
+
+		respData.Data = append(respData.Data, flight)
+		flight = jsonFlight{}
+	}
+	if err = rows.Close(); err != nil {
+		return jsonFlightSearchV2Resp{}, err
+	}
+
+	if scanned == count {
+		respData.NextPageToken = encodePageToken(lastFlight)
+	}
+
+	return respData, nil
+}
+
+// withinDepartureRadius reports whether a schedule entry's "HH:MM" UTC
+// departure time falls within radius of departure's time of day. A
+// malformed utc field is treated as a non-match rather than an error,
+// since schedule data predates this filter.
+func withinDepartureRadius(utc string, departure time.Time, radius time.Duration) bool {
+	scheduled, err := time.Parse("15:04", utc)
+	if err != nil {
+		return false
+	}
+
+	wantMinutes := departure.UTC().Hour()*60 + departure.UTC().Minute()
+	gotMinutes := scheduled.Hour()*60 + scheduled.Minute()
+	diff := gotMinutes - wantMinutes
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return time.Duration(diff)*time.Minute <= radius
+}
+
+// SearchHotelsV2 is the paginated counterpart to GetHotels: it runs the
+// same FTS query but pages through results via an offset-carrying cursor
+// instead of always fetching the first 100 matches.
+func (cr *CBRepository) SearchHotelsV2(ctx context.Context, p hotelSearchV2Params) (jsonHotelSearchV2Resp, error) {
+	if err := ctx.Err(); err != nil {
+		return jsonHotelSearchV2Resp{}, err
+	}
+
+	defer observeCouchbaseDuration("search", time.Now())
+
+	var respData jsonHotelSearchV2Resp
+	var defaultCollection = cr.defaultBucket.DefaultCollection()
+
+	count := p.Count
+	if count <= 0 {
+		count = defaultPageSizeV2
+	}
+
+	skip, _ := strconv.Atoi(decodePageToken(p.PageToken))
+	if skip < 0 {
+		skip = 0
+	}
+
+	qp := search.NewConjunctionQuery(search.NewTermQuery("hotel").Field("type"))
+
+	if p.Location != "" && p.Location != "*" {
+		qp.And(search.NewDisjunctionQuery(
+			search.NewMatchPhraseQuery(p.Location).Field("country"),
+			search.NewMatchPhraseQuery(p.Location).Field("city"),
+			search.NewMatchPhraseQuery(p.Location).Field("state"),
+			search.NewMatchPhraseQuery(p.Location).Field("address"),
+		))
+	}
+
+	if p.Query != "" && p.Query != "*" {
+		qp.And(search.NewDisjunctionQuery(
+			search.NewMatchPhraseQuery(p.Query).Field("description"),
+			search.NewMatchPhraseQuery(p.Query).Field("name"),
+		))
+	}
+
+	logCouchbaseQuery(ctx, "search", "hotels")
+	results, err := cr.cluster.SearchQuery("hotels", qp, &gocb.SearchOptions{
+		Limit:   uint32(count),
+		Skip:    uint32(skip),
+		Timeout: timeoutFromContext(ctx),
+	})
+	if err != nil {
+		return jsonHotelSearchV2Resp{}, err
+	}
+
+	respData.Data = []jsonHotel{}
+	rowCount := 0
+	for results.Next() {
+		if err := ctx.Err(); err != nil {
+			return jsonHotelSearchV2Resp{}, err
+		}
+
+		res, _ := defaultCollection.LookupIn(results.Row().ID, []gocb.LookupInSpec{
+			gocb.GetSpec("country", nil),
+			gocb.GetSpec("city", nil),
+			gocb.GetSpec("state", nil),
+			gocb.GetSpec("address", nil),
+			gocb.GetSpec("name", nil),
+			gocb.GetSpec("description", nil),
+		}, &gocb.LookupInOptions{Timeout: timeoutFromContext(ctx)})
+		// We ignore errors here since some hotels are missing various
+		//  pieces of data, but every key exists since it came from FTS.
+
+		var hotel jsonHotel
+		res.ContentAt(0, &hotel.Country)
+		res.ContentAt(1, &hotel.City)
+		res.ContentAt(2, &hotel.State)
+		res.ContentAt(3, &hotel.Address)
+		res.ContentAt(4, &hotel.Name)
+		res.ContentAt(5, &hotel.Description)
+
+		respData.Data = append(respData.Data, hotel)
+		rowCount++
+	}
+
+	if rowCount == count {
+		respData.NextPageToken = encodePageToken(strconv.Itoa(skip + rowCount))
+	}
+
+	return respData, nil
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+// instrumentedRepository wraps a Repository to record
+// repo_operation_duration_seconds and repo_operation_errors_total around
+// every call, so individual Repository implementations (CBRepository or
+// a test fake) don't need to know about metrics themselves.
+type instrumentedRepository struct {
+	Repository
+	metrics *serverMetrics
+}
+
+func newInstrumentedRepository(db Repository, metrics *serverMetrics) *instrumentedRepository {
+	return &instrumentedRepository{Repository: db, metrics: metrics}
+}
+
+// observe records op's latency and, if it failed, classifies the error
+// for repo_operation_errors_total.
+func (ir *instrumentedRepository) observe(op string, start time.Time, err error) {
+	ir.metrics.repoOperationDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+	if err != nil {
+		ir.metrics.repoOperationErrors.WithLabelValues(op, repoErrorKind(err)).Inc()
+	}
+}
+
+// repoErrorKind classifies a Repository error for the
+// repo_operation_errors_total kind label.
+func repoErrorKind(err error) string {
+	switch {
+	case errors.Is(err, gocb.ErrDocumentNotFound):
+		return "not_found"
+	case errors.Is(err, gocb.ErrDocumentExists):
+		return "already_exists"
+	case errors.Is(err, gocb.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "other"
+	}
+}
+
+func (ir *instrumentedRepository) GetAirports(ctx context.Context, searchKey string) (jsonAirportSearchResp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.GetAirports(ctx, searchKey)
+	ir.observe("GetAirports", start, err)
+	return resp, err
+}
+
+func (ir *instrumentedRepository) GetFlightPaths(ctx context.Context, from, to string, dayOfWeek int) (jsonFlightSearchResp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.GetFlightPaths(ctx, from, to, dayOfWeek)
+	ir.observe("GetFlightPaths", start, err)
+	return resp, err
+}
+
+func (ir *instrumentedRepository) GetHotels(ctx context.Context, description, location string) (jsonHotelSearchResp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.GetHotels(ctx, description, location)
+	ir.observe("GetHotels", start, err)
+	return resp, err
+}
+
+func (ir *instrumentedRepository) CreateUser(ctx context.Context, username, password string) error {
+	start := time.Now()
+	err := ir.Repository.CreateUser(ctx, username, password)
+	ir.observe("CreateUser", start, err)
+	return err
+}
+
+func (ir *instrumentedRepository) VerifyUserPassword(ctx context.Context, username, password string) (bool, error) {
+	start := time.Now()
+	ok, err := ir.Repository.VerifyUserPassword(ctx, username, password)
+	ir.observe("VerifyUserPassword", start, err)
+	return ok, err
+}
+
+func (ir *instrumentedRepository) GetUserFlights(ctx context.Context, username string) (jsonUserFlightsResp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.GetUserFlights(ctx, username)
+	ir.observe("GetUserFlights", start, err)
+	return resp, err
+}
+
+func (ir *instrumentedRepository) UpdateUserFlights(ctx context.Context, username string, flights []jsonBookedFlight) (jsonUserBookFlightResp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.UpdateUserFlights(ctx, username, flights)
+	ir.observe("UpdateUserFlights", start, err)
+	return resp, err
+}
+
+func (ir *instrumentedRepository) GetOrCreateFederatedUser(ctx context.Context, provider, subject string, profile FederatedProfile) (string, error) {
+	start := time.Now()
+	username, err := ir.Repository.GetOrCreateFederatedUser(ctx, provider, subject, profile)
+	ir.observe("GetOrCreateFederatedUser", start, err)
+	return username, err
+}
+
+func (ir *instrumentedRepository) SearchFlightsV2(ctx context.Context, p flightSearchV2Params) (jsonFlightSearchV2Resp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.SearchFlightsV2(ctx, p)
+	ir.observe("SearchFlightsV2", start, err)
+	return resp, err
+}
+
+func (ir *instrumentedRepository) SearchHotelsV2(ctx context.Context, p hotelSearchV2Params) (jsonHotelSearchV2Resp, error) {
+	start := time.Now()
+	resp, err := ir.Repository.SearchHotelsV2(ctx, p)
+	ir.observe("SearchHotelsV2", start, err)
+	return resp, err
+}
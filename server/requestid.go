@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type requestIDKey struct{}
+
+// requestIDMiddleware ensures every request carries a request ID, reusing
+// an inbound X-Request-Id header if present so it can be correlated
+// across a caller's own logs, and otherwise minting a new one.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get("X-Request-Id")
+		if id == "" {
+			if generated, err := uuid.NewRandom(); err == nil {
+				id = generated.String()
+			}
+		}
+
+		w.Header().Set("X-Request-Id", id)
+		ctx := context.WithValue(req.Context(), requestIDKey{}, id)
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// requestIDFromContext returns the request ID stashed by
+// requestIDMiddleware, or "" if none is present (e.g. in a unit test that
+// calls a handler directly).
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -1,24 +1,217 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"log/slog"
 	"net/http"
+	"os"
 	"strings"
+	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/couchbaselabs/try-cb-golang/health"
+	"github.com/couchbaselabs/try-cb-golang/service"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// defaultRequestTimeout bounds how long a handler will wait on the
+// Repository before giving up, absent an explicit WithTimeout option.
+const defaultRequestTimeout = 10 * time.Second
+
+// statusClientClosedRequest mirrors nginx's non-standard 499 code for a
+// client that disconnected before the server could respond.
+const statusClientClosedRequest = 499
+
 type Server struct {
 	router *mux.Router
 	db     Repository
+
+	// RequestTimeout bounds how long each request's derived context stays
+	// valid, so a client disconnect or a slow Couchbase query can't hang
+	// a handler indefinitely.
+	RequestTimeout time.Duration
+
+	// Auth issues and verifies the bearer tokens used on the user-scoped
+	// endpoints.
+	Auth Authenticator
+
+	// Bookings backs the /api/v2/bookings endpoints. It is nil unless db
+	// also implements BookingRepository or a WithBookingRepository option
+	// is supplied; while it is nil, those endpoints respond 500.
+	Bookings BookingRepository
+
+	// Readiness backs GET /readyz. A nil Readiness reports ready
+	// unconditionally, which is the right default for tests that don't
+	// care about a live Couchbase connection.
+	Readiness health.Checker
+
+	// Connectors backs the federated-login routes
+	// /api/user/auth/{connector}/start and /callback, keyed by
+	// AuthConnector.Name(). Empty unless WithAuthConnectors is supplied or
+	// the environment configures a provider's credentials.
+	Connectors map[string]AuthConnector
+
+	// RegistrationRequiresToken gates /api/user/signup behind a valid,
+	// unexhausted registration token when true. False (the default)
+	// keeps signup open, matching existing behavior and tests.
+	RegistrationRequiresToken bool
+
+	// RegistrationTokens backs RegistrationRequiresToken and the
+	// /api/admin/registration_tokens CRUD endpoints. It is nil unless db
+	// also implements RegistrationTokenRepository or a
+	// WithRegistrationTokenRepository option is supplied.
+	RegistrationTokens RegistrationTokenRepository
+
+	// AdminUsers is the set of usernames (the same names Auth issues
+	// tokens for) allowed to call /api/admin/registration_tokens. Empty
+	// unless WithAdminUsers is supplied or ADMIN_USERS is set; while it is
+	// empty, every admin endpoint responds 403.
+	AdminUsers map[string]bool
+
+	// Logger receives structured logs for repository errors encountered
+	// while handling a request.
+	Logger *slog.Logger
+
+	// MetricsRegistry overrides the registry Server's Prometheus
+	// collectors register against and /metrics serves. New builds a
+	// fresh, unshared registry when this is nil, so creating many Server
+	// instances in the same process (as the test suite does) doesn't
+	// panic on duplicate registration; set this to share metrics across
+	// Server instances or to assert on a known set of series in a test.
+	MetricsRegistry *prometheus.Registry
+
+	// metrics holds the collectors this Server's middleware and
+	// instrumentedRepository record to, built from MetricsRegistry in
+	// New.
+	metrics *serverMetrics
+
+	// Users holds the login/signup, travel search and flight booking
+	// business logic shared by this HTTP API and the gRPC API in
+	// service/grpc, built on top of db.
+	Users *service.Service
+}
+
+// Option configures optional Server settings in New.
+type Option func(*Server)
+
+// WithTimeout overrides the default per-request deadline applied to the
+// context passed down to the Repository.
+func WithTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.RequestTimeout = d
+	}
 }
 
-func New(db Repository) *Server {
-	s := &Server{}
+// WithAuthenticator overrides the default HMAC Authenticator, e.g. with an
+// OIDCAuthenticator for verifying federated sessions.
+func WithAuthenticator(auth Authenticator) Option {
+	return func(s *Server) {
+		s.Auth = auth
+	}
+}
+
+// WithBookingRepository overrides the BookingRepository backing
+// /api/v2/bookings, e.g. in tests where db doesn't implement it.
+func WithBookingRepository(br BookingRepository) Option {
+	return func(s *Server) {
+		s.Bookings = br
+	}
+}
+
+// WithReadinessChecker overrides the Checker backing GET /readyz.
+func WithReadinessChecker(checker health.Checker) Option {
+	return func(s *Server) {
+		s.Readiness = checker
+	}
+}
+
+// WithLogger overrides the default slog.Logger used for repository error
+// logging.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Server) {
+		s.Logger = logger
+	}
+}
+
+// WithRegistrationRequiresToken overrides whether /api/user/signup
+// requires a valid registration token.
+func WithRegistrationRequiresToken(required bool) Option {
+	return func(s *Server) {
+		s.RegistrationRequiresToken = required
+	}
+}
+
+// WithRegistrationTokenRepository overrides the RegistrationTokenRepository
+// backing /api/admin/registration_tokens and signup-time gating, e.g. in
+// tests where db doesn't implement it.
+func WithRegistrationTokenRepository(rtr RegistrationTokenRepository) Option {
+	return func(s *Server) {
+		s.RegistrationTokens = rtr
+	}
+}
+
+// WithAdminUsers overrides the set of usernames allowed to call
+// /api/admin/registration_tokens.
+func WithAdminUsers(users ...string) Option {
+	return func(s *Server) {
+		s.AdminUsers = make(map[string]bool, len(users))
+		for _, u := range users {
+			s.AdminUsers[u] = true
+		}
+	}
+}
+
+// WithMetricsRegistry overrides the Prometheus registry Server's
+// collectors register against and /metrics serves, e.g. so tests can
+// assert on an isolated set of series.
+func WithMetricsRegistry(reg *prometheus.Registry) Option {
+	return func(s *Server) {
+		s.MetricsRegistry = reg
+	}
+}
+
+// WithAuthConnectors overrides the default federated-login connectors
+// (normally built from the environment by connectorsFromEnv), e.g. so
+// tests can inject fakes.
+func WithAuthConnectors(connectors ...AuthConnector) Option {
+	return func(s *Server) {
+		s.Connectors = make(map[string]AuthConnector, len(connectors))
+		for _, c := range connectors {
+			s.Connectors[c.Name()] = c
+		}
+	}
+}
+
+func New(db Repository, opts ...Option) *Server {
+	s := &Server{
+		RequestTimeout:            getEnvDuration("REQUEST_TIMEOUT", defaultRequestTimeout),
+		Auth:                      authenticatorFromEnv(),
+		Logger:                    slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+		RegistrationRequiresToken: getEnvBool("REGISTRATION_REQUIRES_TOKEN", false),
+	}
+	if br, ok := db.(BookingRepository); ok {
+		s.Bookings = br
+	}
+	if rtr, ok := db.(RegistrationTokenRepository); ok {
+		s.RegistrationTokens = rtr
+	}
+	WithAuthConnectors(connectorsFromEnv()...)(s)
+	WithAdminUsers(adminUsersFromEnv()...)(s)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.MetricsRegistry == nil {
+		s.MetricsRegistry = prometheus.NewRegistry()
+	}
+	s.metrics = newServerMetrics(s.MetricsRegistry)
+
 	s.setupRoutes()
-	s.db = db
+	s.db = newInstrumentedRepository(db, s.metrics)
+	s.Users = service.New(&serviceRepository{db: s.db})
 
 	return s
 }
@@ -31,6 +224,17 @@ func (s *Server) setupRoutes() {
 	// Create a router for our server
 	s.router = mux.NewRouter()
 
+	// Server.Middleware chain: request-ID correlation, then the access
+	// log, then metrics. Applied inside the router so mux.CurrentRoute is
+	// available to the access log and metrics middleware for per-route
+	// (not per-path-param) labeling.
+	s.router.Use(requestIDMiddleware, s.accessLogMiddleware, s.metricsMiddleware)
+
+	// Liveness/readiness/metrics
+	s.router.Path("/healthz").Methods("GET").HandlerFunc(s.Healthz)
+	s.router.Path("/readyz").Methods("GET").HandlerFunc(s.Readyz)
+	s.router.Path("/metrics").Methods("GET").Handler(promhttp.HandlerFor(s.MetricsRegistry, promhttp.HandlerOpts{}))
+
 	// Set up our REST endpoints
 	s.router.Path("/api/airports").Methods("GET").HandlerFunc(s.AirportSearch)
 	s.router.Path("/api/flightPaths/{from}/{to}").Methods("GET").HandlerFunc(s.FlightSearch)
@@ -38,9 +242,26 @@ func (s *Server) setupRoutes() {
 	s.router.Path("/api/user/signup").Methods("POST").HandlerFunc(s.UserSignup)
 	s.router.Path("/api/user/{username}/flights").Methods("GET").HandlerFunc(s.UserFlights)
 	s.router.Path("/api/user/{username}/flights").Methods("POST").HandlerFunc(s.UserBookFlight)
+	s.router.Path("/api/user/auth/{connector}/start").Methods("GET").HandlerFunc(s.AuthConnectorStart)
+	s.router.Path("/api/user/auth/{connector}/callback").Methods("GET").HandlerFunc(s.AuthConnectorCallback)
 	s.router.Path("/api/hotel/{description}/").Methods("GET").HandlerFunc(s.HotelSearch)
 	s.router.Path("/api/hotel/{description}/{location}/").Methods("GET").HandlerFunc(s.HotelSearch)
 
+	// v2: paginated search plus a standalone booking lifecycle, modelled
+	// on the Standard Covoiturage REST contract.
+	s.router.Path("/api/v2/flights").Methods("GET").HandlerFunc(s.FlightSearchV2)
+	s.router.Path("/api/v2/hotels").Methods("GET").HandlerFunc(s.HotelSearchV2)
+	s.router.Path("/api/v2/bookings").Methods("POST").HandlerFunc(s.CreateBooking)
+	s.router.Path("/api/v2/bookings/{id}").Methods("GET").HandlerFunc(s.GetBookingV2)
+	s.router.Path("/api/v2/bookings/{id}/status").Methods("PATCH").HandlerFunc(s.UpdateBookingStatusV2)
+
+	// Admin: registration tokens gating /api/user/signup.
+	s.router.Path("/api/admin/registration_tokens").Methods("GET").HandlerFunc(s.ListRegistrationTokens)
+	s.router.Path("/api/admin/registration_tokens").Methods("POST").HandlerFunc(s.CreateRegistrationToken)
+	s.router.Path("/api/admin/registration_tokens/{id}").Methods("GET").HandlerFunc(s.GetRegistrationToken)
+	s.router.Path("/api/admin/registration_tokens/{id}").Methods("PUT").HandlerFunc(s.UpdateRegistrationToken)
+	s.router.Path("/api/admin/registration_tokens/{id}").Methods("DELETE").HandlerFunc(s.DeleteRegistrationToken)
+
 	// Serve our public files out of root
 	s.router.PathPrefix("/").Handler(http.FileServer(http.Dir("./public")))
 }
@@ -61,9 +282,23 @@ func writeJsonFailure(w http.ResponseWriter, code int, err error) {
 	w.Write(failBytes)
 }
 
+// statusForErr maps a Repository error to an HTTP status code, surfacing
+// context cancellation/deadlines distinctly from a generic failure.
+func statusForErr(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func decodeReqOrFail(w http.ResponseWriter, req *http.Request, data interface{}) bool {
 	err := json.NewDecoder(req.Body).Decode(data)
 	if err != nil {
+		setRequestErrorReason(req.Context(), "decode_error")
 		writeJsonFailure(w, 500, err)
 		return false
 	}
@@ -77,13 +312,19 @@ func encodeRespOrFail(w http.ResponseWriter, data interface{}) {
 	}
 }
 
-func createJwtToken(user string) (string, error) {
-	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user": user,
-	}).SignedString(jwtSecret)
+// logRepoError logs a Repository failure with the request's correlation
+// ID, matched route and (if known) authenticated user, so an operator can
+// trace a 5xx back to the request that caused it.
+func (s *Server) logRepoError(req *http.Request, user string, err error) {
+	s.Logger.Error("repository error",
+		"request_id", requestIDFromContext(req.Context()),
+		"route", routeLabel(req),
+		"user", user,
+		"err", err,
+	)
 }
 
-func decodeAuthUserOrFail(w http.ResponseWriter, req *http.Request, user *authedUser) bool {
+func (s *Server) decodeAuthUserOrFail(w http.ResponseWriter, req *http.Request, user *authedUser) bool {
 	authHeader := req.Header.Get("Authorization")
 	authHeaderParts := strings.SplitN(authHeader, " ", 2)
 	if authHeaderParts[0] != "Bearer" {
@@ -95,27 +336,33 @@ func decodeAuthUserOrFail(w http.ResponseWriter, req *http.Request, user *authed
 		}
 	}
 
-	authToken := authHeaderParts[1]
-	token, err := jwt.Parse(authToken, func(token *jwt.Token) (interface{}, error) {
-		// Don't forget to validate the alg is what you expect:
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-
-		return jwtSecret, nil
-	})
+	authedU, err := s.Auth.Verify(authHeaderParts[1])
 	if err != nil {
 		writeJsonFailure(w, 400, ErrBadAuthHeader)
 		return false
 	}
 
-	authUser := token.Claims.(jwt.MapClaims)["user"].(string)
-	if authUser == "" {
-		writeJsonFailure(w, 400, ErrBadAuth)
+	*user = authedU
+	setRequestUser(req.Context(), authedU.Name)
+
+	return true
+}
+
+// requireAdmin authenticates req the same way decodeAuthUserOrFail does,
+// then additionally requires the caller's user to be in s.AdminUsers,
+// writing a v2-style error response and returning false otherwise. It
+// guards every /api/admin/registration_tokens handler.
+func (s *Server) requireAdmin(w http.ResponseWriter, req *http.Request) bool {
+	var authUser authedUser
+	if !s.decodeAuthUserOrFail(w, req, &authUser) {
 		return false
 	}
 
-	user.Name = authUser
+	if !s.AdminUsers[authUser.Name] {
+		setRequestErrorReason(req.Context(), "admin_required")
+		writeJsonErrorV2(w, http.StatusForbidden, "admin_required", "this endpoint requires an admin account")
+		return false
+	}
 
 	return true
 }
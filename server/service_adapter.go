@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+
+	"github.com/couchbaselabs/try-cb-golang/service"
+)
+
+// serviceRepository adapts Repository to service.Repository, translating
+// between this package's JSON wire types and service's transport-agnostic
+// domain types so service.Service never depends on the server package.
+type serviceRepository struct {
+	db Repository
+}
+
+func (r *serviceRepository) VerifyUserPassword(ctx context.Context, username, password string) (bool, error) {
+	return r.db.VerifyUserPassword(ctx, username, password)
+}
+
+func (r *serviceRepository) CreateUser(ctx context.Context, username, password string) error {
+	return r.db.CreateUser(ctx, username, password)
+}
+
+func (r *serviceRepository) SearchAirports(ctx context.Context, search string) ([]service.Airport, []string, error) {
+	resp, err := r.db.GetAirports(ctx, search)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	airports := make([]service.Airport, len(resp.Data))
+	for i, a := range resp.Data {
+		airports[i] = service.Airport{AirportName: a.AirportName}
+	}
+	return airports, resp.Context, nil
+}
+
+func (r *serviceRepository) SearchFlightPaths(ctx context.Context, from, to string, dayOfWeek int) ([]service.Flight, []string, error) {
+	resp, err := r.db.GetFlightPaths(ctx, from, to, dayOfWeek)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flights := make([]service.Flight, len(resp.Data))
+	for i, f := range resp.Data {
+		flights[i] = service.Flight(f)
+	}
+	return flights, resp.Context, nil
+}
+
+func (r *serviceRepository) SearchHotels(ctx context.Context, description, location string) ([]service.Hotel, []string, error) {
+	resp, err := r.db.GetHotels(ctx, description, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hotels := make([]service.Hotel, len(resp.Data))
+	for i, h := range resp.Data {
+		hotels[i] = service.Hotel(h)
+	}
+	return hotels, resp.Context, nil
+}
+
+func (r *serviceRepository) GetUserFlights(ctx context.Context, username string) ([]service.BookedFlight, []string, error) {
+	resp, err := r.db.GetUserFlights(ctx, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flights := make([]service.BookedFlight, len(resp.Data))
+	for i, f := range resp.Data {
+		flights[i] = service.BookedFlight(f)
+	}
+	return flights, resp.Context, nil
+}
+
+func (r *serviceRepository) BookFlights(ctx context.Context, username string, flights []service.BookedFlight) ([]service.BookedFlight, []string, error) {
+	bookedFlights := make([]jsonBookedFlight, len(flights))
+	for i, f := range flights {
+		bookedFlights[i] = jsonBookedFlight(f)
+	}
+
+	resp, err := r.db.UpdateUserFlights(ctx, username, bookedFlights)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	added := make([]service.BookedFlight, len(resp.Data.Added))
+	for i, f := range resp.Data.Added {
+		added[i] = service.BookedFlight(f)
+	}
+	return added, resp.Context, nil
+}
@@ -0,0 +1,59 @@
+// Package grpc exposes service.Service over gRPC, generated from the
+// UserService and TravelService definitions in proto/users/v1/users.proto
+// and proto/travel/v1/travel.proto, as an alternative transport to the
+// server package's HTTP API. Regenerate the proto bindings with
+// `buf generate proto` after editing either .proto file.
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/couchbaselabs/try-cb-golang/service"
+	"github.com/couchbaselabs/try-cb-golang/service/grpc/travelv1"
+	"github.com/couchbaselabs/try-cb-golang/service/grpc/usersv1"
+)
+
+// userServiceServer adapts service.Service to the usersv1.UserServiceServer
+// interface generated from users.proto.
+type userServiceServer struct {
+	usersv1.UnimplementedUserServiceServer
+
+	svc *service.Service
+}
+
+func (s *userServiceServer) Login(ctx context.Context, req *usersv1.LoginRequest) (*usersv1.LoginResponse, error) {
+	if err := s.svc.Login(ctx, req.GetUsername(), req.GetPassword()); err != nil {
+		if errors.Is(err, service.ErrBadAuth) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &usersv1.LoginResponse{}, nil
+}
+
+func (s *userServiceServer) Signup(ctx context.Context, req *usersv1.SignupRequest) (*usersv1.SignupResponse, error) {
+	if err := s.svc.Signup(ctx, req.GetUsername(), req.GetPassword()); err != nil {
+		if errors.Is(err, service.ErrUserExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &usersv1.SignupResponse{}, nil
+}
+
+// NewServer returns a *grpc.Server exposing svc's business logic as the
+// UserService and TravelService gRPC APIs, for callers that want to run an
+// RPC transport alongside server.New's HTTP one.
+func NewServer(svc *service.Service) *grpclib.Server {
+	s := grpclib.NewServer()
+	usersv1.RegisterUserServiceServer(s, &userServiceServer{svc: svc})
+	travelv1.RegisterTravelServiceServer(s, &travelServiceServer{svc: svc})
+	return s
+}
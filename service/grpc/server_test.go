@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/couchbase/gocb/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/couchbaselabs/try-cb-golang/service"
+	"github.com/couchbaselabs/try-cb-golang/service/grpc/usersv1"
+)
+
+// fakeRepository is the fake service.Repository shared by every test in
+// this package. Each test only sets the function fields it exercises; see
+// travel_server_test.go for the travel-search fields.
+type fakeRepository struct {
+	verifyUserPasswordFn func(ctx context.Context, username, password string) (bool, error)
+	createUserFn         func(ctx context.Context, username, password string) error
+
+	searchAirportsFn    func(ctx context.Context, search string) ([]service.Airport, []string, error)
+	searchFlightPathsFn func(ctx context.Context, from, to string, dayOfWeek int) ([]service.Flight, []string, error)
+	searchHotelsFn      func(ctx context.Context, description, location string) ([]service.Hotel, []string, error)
+	getUserFlightsFn    func(ctx context.Context, username string) ([]service.BookedFlight, []string, error)
+	bookFlightsFn       func(ctx context.Context, username string, flights []service.BookedFlight) ([]service.BookedFlight, []string, error)
+}
+
+func (fr *fakeRepository) VerifyUserPassword(ctx context.Context, username, password string) (bool, error) {
+	return fr.verifyUserPasswordFn(ctx, username, password)
+}
+
+func (fr *fakeRepository) CreateUser(ctx context.Context, username, password string) error {
+	return fr.createUserFn(ctx, username, password)
+}
+
+func (fr *fakeRepository) SearchAirports(ctx context.Context, search string) ([]service.Airport, []string, error) {
+	return fr.searchAirportsFn(ctx, search)
+}
+
+func (fr *fakeRepository) SearchFlightPaths(ctx context.Context, from, to string, dayOfWeek int) ([]service.Flight, []string, error) {
+	return fr.searchFlightPathsFn(ctx, from, to, dayOfWeek)
+}
+
+func (fr *fakeRepository) SearchHotels(ctx context.Context, description, location string) ([]service.Hotel, []string, error) {
+	return fr.searchHotelsFn(ctx, description, location)
+}
+
+func (fr *fakeRepository) GetUserFlights(ctx context.Context, username string) ([]service.BookedFlight, []string, error) {
+	return fr.getUserFlightsFn(ctx, username)
+}
+
+func (fr *fakeRepository) BookFlights(ctx context.Context, username string, flights []service.BookedFlight) ([]service.BookedFlight, []string, error) {
+	return fr.bookFlightsFn(ctx, username, flights)
+}
+
+func TestUserServiceServerLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title    string
+		users    *fakeRepository
+		wantCode codes.Code
+	}{
+		{
+			title: "ok",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return true, nil
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			title: "bad auth",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, gocb.ErrDocumentNotFound
+				},
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			title: "repository error",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, errors.New("boom")
+				},
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &userServiceServer{svc: service.New(tc.users)}
+			_, err := srv.Login(context.Background(), &usersv1.LoginRequest{Username: "test_user", Password: "test_passw"})
+
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("unexpected status code, got: %v want: %v", status.Code(err), tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestUserServiceServerSignup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title    string
+		users    *fakeRepository
+		wantCode codes.Code
+	}{
+		{
+			title: "ok",
+			users: &fakeRepository{
+				createUserFn: func(ctx context.Context, username, password string) error {
+					return nil
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			title: "user already exists",
+			users: &fakeRepository{
+				createUserFn: func(ctx context.Context, username, password string) error {
+					return gocb.ErrDocumentExists
+				},
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			title: "repository error",
+			users: &fakeRepository{
+				createUserFn: func(ctx context.Context, username, password string) error {
+					return errors.New("boom")
+				},
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &userServiceServer{svc: service.New(tc.users)}
+			_, err := srv.Signup(context.Background(), &usersv1.SignupRequest{Username: "test_user", Password: "test_passw"})
+
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("unexpected status code, got: %v want: %v", status.Code(err), tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/couchbaselabs/try-cb-golang/service"
+	"github.com/couchbaselabs/try-cb-golang/service/grpc/travelv1"
+)
+
+// travelServiceServer adapts service.Service to the travelv1.TravelServiceServer
+// interface generated from travel.proto.
+type travelServiceServer struct {
+	travelv1.UnimplementedTravelServiceServer
+
+	svc *service.Service
+}
+
+func (s *travelServiceServer) SearchAirports(ctx context.Context, req *travelv1.SearchAirportsRequest) (*travelv1.SearchAirportsResponse, error) {
+	airports, _, err := s.svc.SearchAirports(ctx, req.GetSearch())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &travelv1.SearchAirportsResponse{}
+	for _, a := range airports {
+		resp.Airports = append(resp.Airports, &travelv1.Airport{AirportName: a.AirportName})
+	}
+	return resp, nil
+}
+
+func (s *travelServiceServer) SearchFlightPaths(ctx context.Context, req *travelv1.SearchFlightPathsRequest) (*travelv1.SearchFlightPathsResponse, error) {
+	flights, _, err := s.svc.SearchFlightPaths(ctx, req.GetFrom(), req.GetTo(), int(req.GetDayOfWeek()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &travelv1.SearchFlightPathsResponse{}
+	for _, f := range flights {
+		resp.Flights = append(resp.Flights, flightToProto(f))
+	}
+	return resp, nil
+}
+
+func (s *travelServiceServer) SearchHotels(ctx context.Context, req *travelv1.SearchHotelsRequest) (*travelv1.SearchHotelsResponse, error) {
+	hotels, _, err := s.svc.SearchHotels(ctx, req.GetDescription(), req.GetLocation())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &travelv1.SearchHotelsResponse{}
+	for _, h := range hotels {
+		resp.Hotels = append(resp.Hotels, &travelv1.Hotel{
+			Country:     h.Country,
+			City:        h.City,
+			State:       h.State,
+			Address:     h.Address,
+			Name:        h.Name,
+			Description: h.Description,
+		})
+	}
+	return resp, nil
+}
+
+func (s *travelServiceServer) GetUserFlights(ctx context.Context, req *travelv1.GetUserFlightsRequest) (*travelv1.GetUserFlightsResponse, error) {
+	flights, _, err := s.svc.GetUserFlights(ctx, req.GetUsername())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &travelv1.GetUserFlightsResponse{}
+	for _, f := range flights {
+		resp.Flights = append(resp.Flights, bookedFlightToProto(f))
+	}
+	return resp, nil
+}
+
+func (s *travelServiceServer) BookFlights(ctx context.Context, req *travelv1.BookFlightsRequest) (*travelv1.BookFlightsResponse, error) {
+	flights := make([]service.BookedFlight, len(req.GetFlights()))
+	for i, f := range req.GetFlights() {
+		flights[i] = bookedFlightFromProto(f)
+	}
+
+	added, _, err := s.svc.BookFlights(ctx, req.GetUsername(), flights)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &travelv1.BookFlightsResponse{}
+	for _, f := range added {
+		resp.Added = append(resp.Added, bookedFlightToProto(f))
+	}
+	return resp, nil
+}
+
+func flightToProto(f service.Flight) *travelv1.Flight {
+	return &travelv1.Flight{
+		Name:               f.Name,
+		Flight:             f.Flight,
+		Equipment:          f.Equipment,
+		Utc:                f.Utc,
+		SourceAirport:      f.SourceAirport,
+		DestinationAirport: f.DestinationAirport,
+		Price:              f.Price,
+		FlightTime:         int32(f.FlightTime),
+	}
+}
+
+func bookedFlightToProto(f service.BookedFlight) *travelv1.BookedFlight {
+	return &travelv1.BookedFlight{
+		Name:               f.Name,
+		Flight:             f.Flight,
+		Price:              f.Price,
+		Date:               f.Date,
+		SourceAirport:      f.SourceAirport,
+		DestinationAirport: f.DestinationAirport,
+		BookedOn:           f.BookedOn,
+	}
+}
+
+func bookedFlightFromProto(f *travelv1.BookedFlight) service.BookedFlight {
+	return service.BookedFlight{
+		Name:               f.GetName(),
+		Flight:             f.GetFlight(),
+		Price:              f.GetPrice(),
+		Date:               f.GetDate(),
+		SourceAirport:      f.GetSourceAirport(),
+		DestinationAirport: f.GetDestinationAirport(),
+		BookedOn:           f.GetBookedOn(),
+	}
+}
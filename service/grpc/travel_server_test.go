@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/couchbaselabs/try-cb-golang/service"
+	"github.com/couchbaselabs/try-cb-golang/service/grpc/travelv1"
+)
+
+func TestTravelServiceServerSearchAirports(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{
+		searchAirportsFn: func(ctx context.Context, search string) ([]service.Airport, []string, error) {
+			if search != "SFO" {
+				t.Errorf("unexpected search, got: %s want: SFO", search)
+			}
+			return []service.Airport{{AirportName: "San Francisco Intl"}}, nil, nil
+		},
+	}
+
+	srv := &travelServiceServer{svc: service.New(repo)}
+	resp, err := srv.SearchAirports(context.Background(), &travelv1.SearchAirportsRequest{Search: "SFO"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetAirports()) != 1 || resp.GetAirports()[0].GetAirportName() != "San Francisco Intl" {
+		t.Errorf("unexpected airports: %v", resp.GetAirports())
+	}
+}
+
+func TestTravelServiceServerSearchFlightPaths(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{
+		searchFlightPathsFn: func(ctx context.Context, from, to string, dayOfWeek int) ([]service.Flight, []string, error) {
+			return []service.Flight{{Name: "FLIGHT1", Flight: "1234HH"}}, nil, nil
+		},
+	}
+
+	srv := &travelServiceServer{svc: service.New(repo)}
+	resp, err := srv.SearchFlightPaths(context.Background(), &travelv1.SearchFlightPathsRequest{From: "a", To: "b", DayOfWeek: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetFlights()) != 1 || resp.GetFlights()[0].GetFlight() != "1234HH" {
+		t.Errorf("unexpected flights: %v", resp.GetFlights())
+	}
+}
+
+func TestTravelServiceServerSearchHotels(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{
+		searchHotelsFn: func(ctx context.Context, description, location string) ([]service.Hotel, []string, error) {
+			return []service.Hotel{{Country: "UK", Description: "Four Star"}}, nil, nil
+		},
+	}
+
+	srv := &travelServiceServer{svc: service.New(repo)}
+	resp, err := srv.SearchHotels(context.Background(), &travelv1.SearchHotelsRequest{Description: "Four star"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetHotels()) != 1 || resp.GetHotels()[0].GetCountry() != "UK" {
+		t.Errorf("unexpected hotels: %v", resp.GetHotels())
+	}
+}
+
+func TestTravelServiceServerGetUserFlights(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title    string
+		repo     *fakeRepository
+		wantCode codes.Code
+	}{
+		{
+			title: "ok",
+			repo: &fakeRepository{
+				getUserFlightsFn: func(ctx context.Context, username string) ([]service.BookedFlight, []string, error) {
+					if username != "test_user" {
+						t.Errorf("unexpected username, got: %s want: test_user", username)
+					}
+					return []service.BookedFlight{{Name: "FLIGHT1", Flight: "1234HH"}}, nil, nil
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			title: "repository error",
+			repo: &fakeRepository{
+				getUserFlightsFn: func(ctx context.Context, username string) ([]service.BookedFlight, []string, error) {
+					return nil, nil, errors.New("boom")
+				},
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &travelServiceServer{svc: service.New(tc.repo)}
+			resp, err := srv.GetUserFlights(context.Background(), &travelv1.GetUserFlightsRequest{Username: "test_user"})
+
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("unexpected status code, got: %v want: %v", status.Code(err), tc.wantCode)
+			}
+			if tc.wantCode == codes.OK && len(resp.GetFlights()) != 1 {
+				t.Errorf("unexpected flights: %v", resp.GetFlights())
+			}
+		})
+	}
+}
+
+func TestTravelServiceServerBookFlights(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{
+		bookFlightsFn: func(ctx context.Context, username string, flights []service.BookedFlight) ([]service.BookedFlight, []string, error) {
+			if username != "test_user" {
+				t.Errorf("unexpected username, got: %s want: test_user", username)
+			}
+			if len(flights) != 1 || flights[0].Flight != "US229" {
+				t.Errorf("unexpected flights: %v", flights)
+			}
+			return flights, nil, nil
+		},
+	}
+
+	srv := &travelServiceServer{svc: service.New(repo)}
+	resp, err := srv.BookFlights(context.Background(), &travelv1.BookFlightsRequest{
+		Username: "test_user",
+		Flights:  []*travelv1.BookedFlight{{Name: "US Airways", Flight: "US229", SourceAirport: "SFO", DestinationAirport: "LAX", Price: 158.38}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetAdded()) != 1 || resp.GetAdded()[0].GetFlight() != "US229" {
+		t.Errorf("unexpected added flights: %v", resp.GetAdded())
+	}
+}
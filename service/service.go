@@ -0,0 +1,80 @@
+// Package service holds the business logic behind the server package's
+// user-facing HTTP handlers, kept independent of any one transport so
+// other APIs (e.g. the gRPC API in service/grpc) reuse it instead of
+// re-implementing the same rules against the Repository directly.
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+var (
+	// ErrUserExists is returned by Signup when the username is already
+	// registered.
+	ErrUserExists = errors.New("user already exists")
+
+	// ErrBadAuth is returned by Login when the username doesn't exist or
+	// the password doesn't match it. The two cases are folded together so
+	// callers can't use Login to enumerate registered usernames.
+	ErrBadAuth = errors.New("invalid username or password")
+)
+
+// UserRepository is the subset of server.Repository this package needs for
+// Login/Signup. It's restated here, rather than imported, so service has
+// no dependency on the server package it was extracted from.
+type UserRepository interface {
+	VerifyUserPassword(ctx context.Context, username, password string) (bool, error)
+	CreateUser(ctx context.Context, username, password string) error
+}
+
+// Repository is the full subset of server.Repository this package needs,
+// covering both Login/Signup and the airport/flight/hotel/booking search
+// in travel.go.
+type Repository interface {
+	UserRepository
+	TravelRepository
+}
+
+// Service implements the business logic shared by every transport the
+// try-cb-golang API is exposed over.
+type Service struct {
+	repo Repository
+}
+
+// New returns a Service backed by repo.
+func New(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Login verifies username/password, returning ErrBadAuth if the user
+// doesn't exist or the password doesn't match.
+func (s *Service) Login(ctx context.Context, username, password string) error {
+	ok, err := s.repo.VerifyUserPassword(ctx, username, password)
+	if err != nil {
+		if errors.Is(err, gocb.ErrDocumentNotFound) {
+			return ErrBadAuth
+		}
+		return err
+	}
+	if !ok {
+		return ErrBadAuth
+	}
+
+	return nil
+}
+
+// Signup creates username with password, returning ErrUserExists if it's
+// already registered.
+func (s *Service) Signup(ctx context.Context, username, password string) error {
+	if err := s.repo.CreateUser(ctx, username, password); err != nil {
+		if errors.Is(err, gocb.ErrDocumentExists) {
+			return ErrUserExists
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+// fakeRepository is the fake service.Repository shared by every test in
+// this package. Each test only sets the function fields it exercises;
+// see travel_test.go for the SearchAirports/SearchFlightPaths/etc. cases.
+type fakeRepository struct {
+	verifyUserPasswordFn func(ctx context.Context, username, password string) (bool, error)
+	createUserFn         func(ctx context.Context, username, password string) error
+
+	searchAirportsFn    func(ctx context.Context, search string) ([]Airport, []string, error)
+	searchFlightPathsFn func(ctx context.Context, from, to string, dayOfWeek int) ([]Flight, []string, error)
+	searchHotelsFn      func(ctx context.Context, description, location string) ([]Hotel, []string, error)
+	getUserFlightsFn    func(ctx context.Context, username string) ([]BookedFlight, []string, error)
+	bookFlightsFn       func(ctx context.Context, username string, flights []BookedFlight) ([]BookedFlight, []string, error)
+}
+
+func (fr *fakeRepository) VerifyUserPassword(ctx context.Context, username, password string) (bool, error) {
+	return fr.verifyUserPasswordFn(ctx, username, password)
+}
+
+func (fr *fakeRepository) CreateUser(ctx context.Context, username, password string) error {
+	return fr.createUserFn(ctx, username, password)
+}
+
+func (fr *fakeRepository) SearchAirports(ctx context.Context, search string) ([]Airport, []string, error) {
+	return fr.searchAirportsFn(ctx, search)
+}
+
+func (fr *fakeRepository) SearchFlightPaths(ctx context.Context, from, to string, dayOfWeek int) ([]Flight, []string, error) {
+	return fr.searchFlightPathsFn(ctx, from, to, dayOfWeek)
+}
+
+func (fr *fakeRepository) SearchHotels(ctx context.Context, description, location string) ([]Hotel, []string, error) {
+	return fr.searchHotelsFn(ctx, description, location)
+}
+
+func (fr *fakeRepository) GetUserFlights(ctx context.Context, username string) ([]BookedFlight, []string, error) {
+	return fr.getUserFlightsFn(ctx, username)
+}
+
+func (fr *fakeRepository) BookFlights(ctx context.Context, username string, flights []BookedFlight) ([]BookedFlight, []string, error) {
+	return fr.bookFlightsFn(ctx, username, flights)
+}
+
+func TestServiceLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title   string
+		users   *fakeRepository
+		wantErr error
+	}{
+		{
+			title: "ok",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return true, nil
+				},
+			},
+		},
+		{
+			title: "user not found",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, gocb.ErrDocumentNotFound
+				},
+			},
+			wantErr: ErrBadAuth,
+		},
+		{
+			title: "password mismatch",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, nil
+				},
+			},
+			wantErr: ErrBadAuth,
+		},
+		{
+			title: "repository error",
+			users: &fakeRepository{
+				verifyUserPasswordFn: func(ctx context.Context, username, password string) (bool, error) {
+					return false, errors.New("boom")
+				},
+			},
+			wantErr: errors.New("boom"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			err := New(tc.users).Login(context.Background(), "test_user", "test_passw")
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tc.wantErr.Error() {
+				t.Errorf("unexpected error, got: %v want: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceSignup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title   string
+		users   *fakeRepository
+		wantErr error
+	}{
+		{
+			title: "ok",
+			users: &fakeRepository{
+				createUserFn: func(ctx context.Context, username, password string) error {
+					return nil
+				},
+			},
+		},
+		{
+			title: "user already exists",
+			users: &fakeRepository{
+				createUserFn: func(ctx context.Context, username, password string) error {
+					return gocb.ErrDocumentExists
+				},
+			},
+			wantErr: ErrUserExists,
+		},
+		{
+			title: "repository error",
+			users: &fakeRepository{
+				createUserFn: func(ctx context.Context, username, password string) error {
+					return errors.New("boom")
+				},
+			},
+			wantErr: errors.New("boom"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			err := New(tc.users).Signup(context.Background(), "test_user", "test_passw")
+			if tc.wantErr == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tc.wantErr.Error() {
+				t.Errorf("unexpected error, got: %v want: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
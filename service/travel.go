@@ -0,0 +1,85 @@
+package service
+
+import "context"
+
+// Airport is an airport search result, as returned by SearchAirports.
+type Airport struct {
+	AirportName string
+}
+
+// Flight is a scheduled flight, as returned by SearchFlightPaths.
+type Flight struct {
+	Name               string
+	Flight             string
+	Equipment          string
+	Utc                string
+	SourceAirport      string
+	DestinationAirport string
+	Price              float64
+	FlightTime         int
+}
+
+// Hotel is a hotel search result, as returned by SearchHotels.
+type Hotel struct {
+	Country     string
+	City        string
+	State       string
+	Address     string
+	Name        string
+	Description string
+}
+
+// BookedFlight is one flight in a user's booking: a pending booking
+// request when passed to BookFlights (Date and BookedOn are unset), or an
+// existing booking as returned by GetUserFlights or BookFlights.
+type BookedFlight struct {
+	Name               string
+	Flight             string
+	Price              float64
+	Date               string
+	SourceAirport      string
+	DestinationAirport string
+	BookedOn           string
+}
+
+// TravelRepository is the subset of server.Repository needed for airport,
+// flight and hotel search, and for a user's own booked flights. It's
+// restated in this package's own domain types (see Airport, Flight, Hotel,
+// BookedFlight above) rather than server's JSON wire types, so service has
+// no dependency on the server package. Each search method also returns the
+// N1QL/FTS query string(s) issued, which callers may surface for
+// debugging the same way the HTTP API's "context" field always has.
+type TravelRepository interface {
+	SearchAirports(ctx context.Context, search string) ([]Airport, []string, error)
+	SearchFlightPaths(ctx context.Context, from, to string, dayOfWeek int) ([]Flight, []string, error)
+	SearchHotels(ctx context.Context, description, location string) ([]Hotel, []string, error)
+	GetUserFlights(ctx context.Context, username string) ([]BookedFlight, []string, error)
+	BookFlights(ctx context.Context, username string, flights []BookedFlight) ([]BookedFlight, []string, error)
+}
+
+// SearchAirports finds airports matching search.
+func (s *Service) SearchAirports(ctx context.Context, search string) ([]Airport, []string, error) {
+	return s.repo.SearchAirports(ctx, search)
+}
+
+// SearchFlightPaths finds scheduled flights from the FAA code from to to
+// on dayOfWeek.
+func (s *Service) SearchFlightPaths(ctx context.Context, from, to string, dayOfWeek int) ([]Flight, []string, error) {
+	return s.repo.SearchFlightPaths(ctx, from, to, dayOfWeek)
+}
+
+// SearchHotels finds hotels matching description and, if given, location.
+func (s *Service) SearchHotels(ctx context.Context, description, location string) ([]Hotel, []string, error) {
+	return s.repo.SearchHotels(ctx, description, location)
+}
+
+// GetUserFlights returns the flights username has already booked.
+func (s *Service) GetUserFlights(ctx context.Context, username string) ([]BookedFlight, []string, error) {
+	return s.repo.GetUserFlights(ctx, username)
+}
+
+// BookFlights adds flights to username's booked flights, returning the
+// full set of newly added flights.
+func (s *Service) BookFlights(ctx context.Context, username string, flights []BookedFlight) ([]BookedFlight, []string, error) {
+	return s.repo.BookFlights(ctx, username, flights)
+}
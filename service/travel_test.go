@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServiceSearchAirports(t *testing.T) {
+	t.Parallel()
+
+	wantData := []Airport{{AirportName: "San Francisco Intl"}}
+	wantCtx := []string{"test"}
+
+	testCases := []struct {
+		title   string
+		repo    *fakeRepository
+		wantErr error
+	}{
+		{
+			title: "ok",
+			repo: &fakeRepository{
+				searchAirportsFn: func(ctx context.Context, search string) ([]Airport, []string, error) {
+					return wantData, wantCtx, nil
+				},
+			},
+		},
+		{
+			title: "repository error",
+			repo: &fakeRepository{
+				searchAirportsFn: func(ctx context.Context, search string) ([]Airport, []string, error) {
+					return nil, nil, errors.New("boom")
+				},
+			},
+			wantErr: errors.New("boom"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			data, queryCtx, err := New(tc.repo).SearchAirports(context.Background(), "SFO")
+			if tc.wantErr != nil {
+				if err == nil || err.Error() != tc.wantErr.Error() {
+					t.Errorf("unexpected error, got: %v want: %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(data, wantData); diff != "" {
+				t.Errorf("invalid data: \ngot: %#v, \nwant: %#v", data, wantData)
+			}
+			if diff := cmp.Diff(queryCtx, wantCtx); diff != "" {
+				t.Errorf("invalid query context: \ngot: %#v, \nwant: %#v", queryCtx, wantCtx)
+			}
+		})
+	}
+}
+
+func TestServiceSearchFlightPaths(t *testing.T) {
+	t.Parallel()
+
+	wantData := []Flight{{Name: "FLIGHT1", Flight: "1234HH"}}
+
+	repo := &fakeRepository{
+		searchFlightPathsFn: func(ctx context.Context, from, to string, dayOfWeek int) ([]Flight, []string, error) {
+			if from != "airport_a" || to != "airport_b" || dayOfWeek != 2 {
+				t.Errorf("unexpected params, got: %s/%s/%d want: airport_a/airport_b/2", from, to, dayOfWeek)
+			}
+			return wantData, []string{"test"}, nil
+		},
+	}
+
+	data, _, err := New(repo).SearchFlightPaths(context.Background(), "airport_a", "airport_b", 2)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(data, wantData); diff != "" {
+		t.Errorf("invalid data: \ngot: %#v, \nwant: %#v", data, wantData)
+	}
+}
+
+func TestServiceSearchHotels(t *testing.T) {
+	t.Parallel()
+
+	wantData := []Hotel{{Country: "UK", Description: "Four Star"}}
+
+	repo := &fakeRepository{
+		searchHotelsFn: func(ctx context.Context, description, location string) ([]Hotel, []string, error) {
+			if description != "Four star" || location != "London" {
+				t.Errorf("unexpected params, got: %s/%s want: Four star/London", description, location)
+			}
+			return wantData, []string{"test"}, nil
+		},
+	}
+
+	data, _, err := New(repo).SearchHotels(context.Background(), "Four star", "London")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(data, wantData); diff != "" {
+		t.Errorf("invalid data: \ngot: %#v, \nwant: %#v", data, wantData)
+	}
+}
+
+func TestServiceGetUserFlights(t *testing.T) {
+	t.Parallel()
+
+	wantData := []BookedFlight{{Name: "FLIGHT1", Flight: "1234HH"}}
+
+	repo := &fakeRepository{
+		getUserFlightsFn: func(ctx context.Context, username string) ([]BookedFlight, []string, error) {
+			if username != "test_user" {
+				t.Errorf("unexpected username, got: %s want: test_user", username)
+			}
+			return wantData, []string{"test"}, nil
+		},
+	}
+
+	data, _, err := New(repo).GetUserFlights(context.Background(), "test_user")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(data, wantData); diff != "" {
+		t.Errorf("invalid data: \ngot: %#v, \nwant: %#v", data, wantData)
+	}
+}
+
+func TestServiceBookFlights(t *testing.T) {
+	t.Parallel()
+
+	flights := []BookedFlight{{Name: "US Airways", Flight: "US229", SourceAirport: "SFO", DestinationAirport: "LAX", Price: 158.38}}
+
+	repo := &fakeRepository{
+		bookFlightsFn: func(ctx context.Context, username string, got []BookedFlight) ([]BookedFlight, []string, error) {
+			if username != "test_user" {
+				t.Errorf("unexpected username, got: %s want: test_user", username)
+			}
+			if diff := cmp.Diff(got, flights); diff != "" {
+				t.Errorf("invalid flights: \ngot: %#v, \nwant: %#v", got, flights)
+			}
+			return flights, []string{"test"}, nil
+		},
+	}
+
+	data, _, err := New(repo).BookFlights(context.Background(), "test_user", flights)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(data, flights); diff != "" {
+		t.Errorf("invalid data: \ngot: %#v, \nwant: %#v", data, flights)
+	}
+}